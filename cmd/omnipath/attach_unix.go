@@ -0,0 +1,79 @@
+//go:build !windows
+
+package omnipath
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/adammpkins/OmniPath/internal/proc"
+	"github.com/adammpkins/OmniPath/internal/tui"
+	"github.com/charmbracelet/x/term"
+	"github.com/creack/pty"
+)
+
+// attachService runs s in the foreground on a pseudo-terminal of its own,
+// with the real terminal in raw mode and wired straight to it, so programs
+// that check isatty or read keys one at a time behave as if started from a
+// shell. It returns once the service exits.
+func attachService(s tui.Service) error {
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return errors.New("--attach needs a terminal on standard input")
+	}
+
+	c := proc.Command(s.Command)
+	c.Env = serviceEnv(s)
+	c.Dir = s.Dir
+	// pty.Start makes the service a session leader, which already gives it
+	// a process group of its own; asking for another would fail.
+	c.SysProcAttr.Setpgid = false
+
+	log.Printf("Attaching to %s: %s\n", s.Name, s.Command)
+	ptmx, err := pty.Start(c)
+	if err != nil {
+		return fmt.Errorf("starting %s on a pseudo-terminal: %w", s.Name, err)
+	}
+	defer ptmx.Close()
+	proc.Track(c)
+	defer proc.Release(c)
+
+	// Keep the service's terminal the size of the real one.
+	resize := make(chan os.Signal, 1)
+	signal.Notify(resize, syscall.SIGWINCH)
+	defer func() {
+		signal.Stop(resize)
+		close(resize) // Ends the goroutine below.
+	}()
+	go func() {
+		for range resize {
+			_ = pty.InheritSize(os.Stdin, ptmx)
+		}
+	}()
+	resize <- syscall.SIGWINCH
+
+	state, err := term.MakeRaw(os.Stdin.Fd())
+	if err != nil {
+		return fmt.Errorf("switching the terminal to raw mode: %w", err)
+	}
+	defer term.Restore(os.Stdin.Fd(), state)
+	// Exiting on SIGTERM or SIGHUP skips the deferred restore.
+	defer onSignalExit(func() { _ = term.Restore(os.Stdin.Fd(), state) })()
+
+	var out io.Writer = os.Stdout
+	if logFile := openServiceLog(s); logFile != nil {
+		defer logFile.Close()
+		out = io.MultiWriter(os.Stdout, logFile)
+	}
+	// The copy from stdin blocks until the next key press after the service
+	// exits, so it is left to end with OmniPath.
+	go func() { _, _ = io.Copy(ptmx, os.Stdin) }()
+	// Reading the pseudo-terminal fails once the service and its children
+	// have closed it, which is when the service is done.
+	_, _ = io.Copy(out, ptmx)
+	return c.Wait()
+}
@@ -0,0 +1,15 @@
+//go:build windows
+
+package omnipath
+
+import (
+	"errors"
+
+	"github.com/adammpkins/OmniPath/internal/tui"
+)
+
+// attachService would run s on a pseudo-terminal of its own, which isn't
+// supported on Windows.
+func attachService(s tui.Service) error {
+	return errors.New("--attach isn't supported on Windows")
+}
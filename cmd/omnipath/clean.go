@@ -0,0 +1,98 @@
+package omnipath
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/adammpkins/OmniPath/internal/config"
+	"github.com/adammpkins/OmniPath/internal/recent"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cleanDryRun bool
+	cleanAll    bool
+	cleanYes    bool
+)
+
+var cleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Remove OmniPath's caches and remembered state",
+	Long:  "Remove OmniPath's cache directory and the state it remembers between runs, such as recently opened docs. The project and the config file are left alone unless --all is given. Logs written with run --log-dir are in a directory of your choosing and aren't removed.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paths, err := cleanPaths(cleanAll)
+		if err != nil {
+			return err
+		}
+		var existing []string
+		for _, path := range paths {
+			if _, err := os.Lstat(path); err == nil {
+				existing = append(existing, path)
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		}
+		if len(existing) == 0 {
+			fmt.Println("Nothing to clean.")
+			return nil
+		}
+
+		for _, path := range existing {
+			fmt.Println(path)
+		}
+		if cleanDryRun {
+			return nil
+		}
+		if !cleanYes {
+			fmt.Print("Remove these? [y/N] ")
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			answer = strings.ToLower(strings.TrimSpace(answer))
+			if answer != "y" && answer != "yes" {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+		for _, path := range existing {
+			if err := os.RemoveAll(path); err != nil {
+				return fmt.Errorf("removing %s: %w", path, err)
+			}
+		}
+		fmt.Printf("Removed %d item(s).\n", len(existing))
+		return nil
+	},
+}
+
+// cleanPaths returns the files and directories OmniPath creates for itself:
+// its cache directory and remembered state, plus the config file if all is
+// set.
+func cleanPaths(all bool) ([]string, error) {
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("locating cache directory: %w", err)
+	}
+	recentPath, err := recent.Path()
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{filepath.Join(cache, "omnipath"), recentPath}
+	if all {
+		configPath, err := config.Path()
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, configPath)
+	}
+	return paths, nil
+}
+
+func init() {
+	cleanCmd.Flags().BoolVar(&cleanDryRun, "dry-run", false, "List what would be removed without removing it")
+	cleanCmd.Flags().BoolVar(&cleanAll, "all", false, "Also remove the config file")
+	cleanCmd.Flags().BoolVarP(&cleanYes, "yes", "y", false, "Remove without asking for confirmation")
+	rootCmd.AddCommand(cleanCmd)
+}
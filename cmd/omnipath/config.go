@@ -0,0 +1,52 @@
+package omnipath
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/adammpkins/OmniPath/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the global OmniPath config file",
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create a commented config file listing every setting",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := config.Path()
+		if err != nil {
+			return err
+		}
+		if err := config.Init(path); err != nil {
+			if errors.Is(err, fs.ErrExist) {
+				return fmt.Errorf("%s already exists", path)
+			}
+			return fmt.Errorf("creating config file: %w", err)
+		}
+		fmt.Printf("Created %s\n", path)
+		return nil
+	},
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the location of the config file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := config.Path()
+		if err != nil {
+			return err
+		}
+		fmt.Println(path)
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configInitCmd, configPathCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -0,0 +1,72 @@
+package omnipath
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/adammpkins/OmniPath/internal/detect"
+	"github.com/adammpkins/OmniPath/internal/docs"
+	"github.com/adammpkins/OmniPath/internal/git"
+	"github.com/adammpkins/OmniPath/internal/tui"
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+)
+
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Show the project's services, dependencies and repository on one screen",
+	Long:  "Show the project's services, dependencies and git remote on one screen, with quick actions to run a service, open documentation or open the repository. Running omnipath without a subcommand opens the dashboard too.",
+	RunE:  runDashboard,
+}
+
+// runDashboard gathers the project overview from the same detectors as the
+// other commands, shows it, and then performs the chosen quick action.
+func runDashboard(cmd *cobra.Command, args []string) error {
+	deps, err := docs.DetectDependencies()
+	if err != nil && !errors.Is(err, docs.ErrNoDependencies) {
+		return fmt.Errorf("detecting dependencies: %w", err)
+	}
+	overview := tui.Overview{
+		Services:     toTUIServices(detect.GetServices()),
+		Dependencies: deps,
+	}
+	if wd, err := os.Getwd(); err == nil {
+		overview.Project = filepath.Base(wd)
+	}
+	// Outside a repository, or without a remote, the dashboard says so.
+	if remote, err := git.GetRemote(); err == nil {
+		if url, err := git.ParseRemoteURL(remote); err == nil {
+			overview.RepoURL = url
+		}
+	}
+	if branch, err := git.CurrentBranch(); err == nil {
+		overview.Branch = branch
+	}
+
+	action, err := tui.RunDashboard(overview)
+	if err != nil {
+		return fmt.Errorf("running dashboard: %w", err)
+	}
+	switch action.Kind {
+	case tui.ActionRun:
+		runServiceNames = []string{action.Service.Name}
+		return runCmd.RunE(runCmd, nil)
+	case tui.ActionOpenDoc:
+		return openDoc(action.Dependency.Name, action.Dependency.DocURL)
+	case tui.ActionOpenRepo:
+		return openURL("repository", overview.RepoURL)
+	}
+	return nil
+}
+
+// isInteractive reports whether OmniPath is attached to a terminal, so the
+// dashboard can be shown.
+func isInteractive() bool {
+	return term.IsTerminal(os.Stdin.Fd()) && term.IsTerminal(os.Stdout.Fd())
+}
+
+func init() {
+	rootCmd.AddCommand(dashboardCmd)
+}
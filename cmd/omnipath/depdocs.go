@@ -0,0 +1,61 @@
+package omnipath
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/adammpkins/OmniPath/internal/docs"
+	"github.com/adammpkins/OmniPath/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	depdocsIncludeAll bool
+	depdocsJSON       bool
+	depdocsFormat     string
+)
+
+var depdocsCmd = &cobra.Command{
+	Use:   "depdocs",
+	Short: "Print the detected dependencies and their documentation URLs",
+	Long:  "Print the detected dependencies without opening a browser, for scripts and editor integrations. Exits with status 2 when no dependencies are found.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := depdocsFormat
+		if depdocsJSON {
+			format = "json"
+		}
+		if format != "json" && format != "table" {
+			return fmt.Errorf("unknown format %q: use json or table", format)
+		}
+
+		detectDependencies := docs.DetectDependencies
+		if depdocsIncludeAll {
+			detectDependencies = docs.DetectAllDependencies
+		}
+		deps, err := detectDependencies()
+		if err != nil {
+			return fmt.Errorf("detecting dependencies: %w", err)
+		}
+		docs.Sort(deps)
+
+		if format == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(deps); err != nil {
+				return fmt.Errorf("encoding dependencies: %w", err)
+			}
+			return nil
+		}
+
+		return output.Table(os.Stdout, output.DependencyHeader, output.DependencyRows(deps))
+	},
+}
+
+func init() {
+	depdocsCmd.Flags().BoolVar(&depdocsJSON, "json", false, "Print the dependencies as JSON (same as --format json)")
+	depdocsCmd.Flags().StringVar(&depdocsFormat, "format", "table", "Output format: table or json")
+	depdocsCmd.Flags().BoolVar(&depdocsIncludeAll, "include-all", false, "Also list packages without curated docs, linked to their package registry page")
+	depdocsCmd.Flags().IntVar(&docs.MaxDepth, "max-depth", 0, "Scan at most this many directory levels below the project root for dependencies (0 for no limit)")
+	rootCmd.AddCommand(depdocsCmd)
+}
@@ -1,22 +1,81 @@
 package omnipath
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"time"
 
 	"github.com/adammpkins/OmniPath/internal/browser"
+	"github.com/adammpkins/OmniPath/internal/config"
 	"github.com/adammpkins/OmniPath/internal/docs"
+	"github.com/adammpkins/OmniPath/internal/output"
+	"github.com/adammpkins/OmniPath/internal/recent"
 	"github.com/adammpkins/OmniPath/internal/tui"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
+var (
+	docsRecent      bool
+	docsClearRecent bool
+	docsIncludeAll  bool
+	docsKeepOpen    bool
+	docsAll         bool
+	docsMax         int
+	docsOutput      string
+)
+
+// docsOpenDelay spaces out the tabs opened by --all so the browser isn't
+// flooded with simultaneous requests.
+const docsOpenDelay = 300 * time.Millisecond
+
 var docsCmd = &cobra.Command{
 	Use:   "docs",
 	Short: "Open dependency documentation for the current project",
-	Run: func(cmd *cobra.Command, args []string) {
-		deps, err := docs.DetectDependencies()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if docsClearRecent {
+			if err := recent.Clear(); err != nil {
+				return fmt.Errorf("clearing recent docs: %w", err)
+			}
+			fmt.Println("Cleared recently opened docs.")
+			return nil
+		}
+		if docsRecent {
+			return openRecentDoc()
+		}
+
+		detectDependencies := docs.DetectDependencies
+		if docsIncludeAll {
+			detectDependencies = docs.DetectAllDependencies
+		}
+		deps, err := detectDependencies()
 		if err != nil {
-			log.Fatalf("Error detecting dependencies: %v", err)
+			return fmt.Errorf("detecting dependencies: %w", err)
+		}
+
+		if docsOutput != "" {
+			return printDependencies(deps, docsOutput)
+		}
+
+		if docsAll {
+			return openAllDocs(deps)
+		}
+
+		if docsKeepOpen {
+			// Keep the selector open so several docs can be opened in turn.
+			err := tui.BrowseDependencies(deps, func(dep docs.DependencyDocs) error {
+				if err := browser.OpenURL(dep.DocURL); err != nil {
+					return err
+				}
+				recordRecentDoc(dep.Name, dep.DocURL)
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("browsing dependencies: %w", err)
+			}
+			return nil
 		}
 
 		var selected docs.DependencyDocs
@@ -26,17 +85,93 @@ var docsCmd = &cobra.Command{
 			// Use our interactive Bubbletea selector.
 			selected, err = tui.SelectDependency(deps)
 			if err != nil {
-				log.Fatalf("Error selecting dependency: %v", err)
+				return fmt.Errorf("selecting dependency: %w", err)
 			}
 		}
 
-		fmt.Printf("Opening documentation for %s: %s\n", selected.Name, selected.DocURL)
-		if err := browser.OpenURL(selected.DocURL); err != nil {
-			log.Fatalf("Failed to open browser: %v", err)
-		}
+		return openDoc(selected.Name, selected.DocURL)
 	},
 }
 
+// openDoc opens the documentation page of the dependency called name and
+// remembers it for --recent.
+func openDoc(name, url string) error {
+	if err := openURL("documentation for "+name, url); err != nil {
+		return err
+	}
+	if !viper.GetBool(config.KeyPrintMode) {
+		recordRecentDoc(name, url)
+	}
+	return nil
+}
+
+// recordRecentDoc remembers an opened documentation page. Failing to is only
+// logged, since the page was opened all the same.
+func recordRecentDoc(name, url string) {
+	if err := recent.Record(name, url); err != nil {
+		log.Printf("Couldn't remember %s in recent docs: %v", name, err)
+	}
+}
+
+// openRecentDoc lets the user pick one of the documentation pages opened
+// before, in any project, and opens it again.
+func openRecentDoc() error {
+	entries, err := recent.Load()
+	if err != nil {
+		return fmt.Errorf("loading recent docs: %w", err)
+	}
+	if len(entries) == 0 {
+		return errors.New("no docs opened yet; open some with omnipath docs first")
+	}
+	selected, err := tui.SelectRecent(entries)
+	if err != nil {
+		return fmt.Errorf("selecting recent docs: %w", err)
+	}
+	return openDoc(selected.Name, selected.URL)
+}
+
+// openAllDocs opens the documentation of every dependency, languages and
+// frameworks first, up to --max of them. In print mode every URL is printed instead, since that opens no tabs.
+func openAllDocs(deps []docs.DependencyDocs) error {
+	docs.Sort(deps)
+	printMode := viper.GetBool(config.KeyPrintMode)
+	if !printMode && len(deps) > docsMax {
+		log.Printf("Found %d dependencies; only opening the first %d (raise --max to open more)", len(deps), docsMax)
+		deps = deps[:docsMax]
+	}
+	for i, dep := range deps {
+		if i > 0 && !printMode {
+			time.Sleep(docsOpenDelay)
+		}
+		if err := openDoc(dep.Name, dep.DocURL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printDependencies prints deps in the given --output format, sorted as
+// they are listed in the selector.
+func printDependencies(deps []docs.DependencyDocs, format string) error {
+	format, err := output.ParseFormat(format)
+	if err != nil {
+		return err
+	}
+	docs.Sort(deps)
+	if format == output.FormatJSON {
+		return output.JSON(os.Stdout, output.Dependencies{SchemaVersion: output.SchemaVersion, Dependencies: deps})
+	}
+	return output.Table(os.Stdout, output.DependencyHeader, output.DependencyRows(deps))
+}
+
 func init() {
+	docsCmd.Flags().BoolVar(&docsAll, "all", false, "Open the documentation of every detected dependency without prompting")
+	docsCmd.Flags().IntVar(&docsMax, "max", 10, "Most tabs --all opens at once")
+	docsCmd.Flags().BoolVarP(&docsKeepOpen, "keep-open", "k", false, "Keep the selector open after opening a doc so several can be opened; quit with q or Esc")
+	docsCmd.Flags().BoolVar(&docsIncludeAll, "include-all", false, "Also list packages without curated docs, linked to their package registry page")
+	docsCmd.Flags().IntVar(&docs.MaxDepth, "max-depth", 0, "Scan at most this many directory levels below the project root for dependencies (0 for no limit)")
+	docsCmd.Flags().BoolVar(&docsRecent, "recent", false, "Pick from the docs opened before in any project, most recent first")
+	docsCmd.Flags().StringVarP(&docsOutput, "output", "o", "", "Print the detected dependencies as text or json instead of opening one")
+	docsCmd.Flags().BoolVar(&docsClearRecent, "clear-recent", false, "Forget the docs opened before and exit")
 	rootCmd.AddCommand(docsCmd)
 }
@@ -0,0 +1,148 @@
+package omnipath
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/adammpkins/OmniPath/internal/browser"
+	"github.com/adammpkins/OmniPath/internal/detect"
+	"github.com/adammpkins/OmniPath/internal/docs"
+	"github.com/adammpkins/OmniPath/internal/git"
+	"github.com/adammpkins/OmniPath/internal/output"
+	"github.com/adammpkins/OmniPath/internal/version"
+	"github.com/spf13/cobra"
+)
+
+var doctorOutput string
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Show what OmniPath detects in this project, for troubleshooting and bug reports",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := output.ParseFormat(doctorOutput)
+		if err != nil {
+			return err
+		}
+		report := diagnose()
+		if format == output.FormatJSON {
+			return output.JSON(os.Stdout, report)
+		}
+
+		fmt.Printf("OmniPath %s on %s/%s\n", version.Get(), report.OS, report.Arch)
+
+		fmt.Println("\nDetectors:")
+		for _, r := range report.Detectors {
+			if !r.Matched {
+				fmt.Printf("  [ ] %s\n", r.Name)
+				continue
+			}
+			fmt.Printf("  [x] %s\n", r.Name)
+			for _, s := range r.Services {
+				fmt.Printf("        %s: %s\n", s.Name, s.Command)
+			}
+		}
+
+		fmt.Println("\nServices offered by 'omnipath run':")
+		if len(report.Services) == 0 {
+			fmt.Println("  none")
+		}
+		for _, s := range report.Services {
+			mode := "foreground"
+			if s.Interactive {
+				mode = "interactive"
+			}
+			fmt.Printf("  %s (%s, priority %d)\n", s.Name, mode, s.Priority)
+		}
+
+		fmt.Println("\nDependencies:")
+		switch {
+		case report.DependencyError != "":
+			fmt.Printf("  error: %s\n", report.DependencyError)
+		case len(report.Dependencies) == 0:
+			fmt.Println("  none")
+		}
+		for _, d := range report.Dependencies {
+			fmt.Printf("  %s [%s]: %s (%s)\n", d.Name, d.Category, d.DocURL, d.Source)
+		}
+
+		fmt.Println("\nGit:")
+		switch {
+		case report.Git.Remote == "":
+			fmt.Printf("  origin: %s\n", report.Git.Error)
+		case report.Git.Error != "":
+			fmt.Printf("  origin: %s (%s)\n", report.Git.Remote, report.Git.Error)
+		default:
+			fmt.Printf("  origin: %s -> %s\n", report.Git.Remote, report.Git.URL)
+		}
+		switch {
+		case report.Git.Detached:
+			fmt.Printf("  HEAD: detached at %s\n", report.Git.Branch)
+		case report.Git.Branch != "":
+			fmt.Printf("  branch: %s\n", report.Git.Branch)
+		}
+
+		fmt.Println("\nBrowser:")
+		switch {
+		case report.Browser.Path != "":
+			fmt.Printf("  launcher: %s\n", report.Browser.Path)
+		case report.Browser.Launcher != "":
+			fmt.Printf("  launcher: %s not found on PATH\n", report.Browser.Launcher)
+		default:
+			fmt.Printf("  launcher: %s\n", report.Browser.Error)
+		}
+		return nil
+	},
+}
+
+// diagnose gathers what doctor reports about the current directory.
+func diagnose() output.Doctor {
+	report := output.Doctor{
+		SchemaVersion: output.SchemaVersion,
+		Version:       version.Get().Version,
+		OS:            runtime.GOOS,
+		Arch:          runtime.GOARCH,
+		Detectors:     detect.Diagnose(),
+		Services:      append([]detect.Service{}, detect.GetServices()...),
+		Dependencies:  []docs.DependencyDocs{},
+	}
+
+	if deps, err := docs.DetectDependencies(); err != nil {
+		report.DependencyError = err.Error()
+	} else {
+		report.Dependencies = deps
+	}
+
+	if remote, err := git.GetRemote(); err != nil {
+		report.Git.Error = err.Error()
+	} else {
+		report.Git.Remote = remote
+		if url, err := git.ParseRemoteURL(remote); err != nil {
+			report.Git.Error = err.Error()
+		} else {
+			report.Git.URL = url
+		}
+	}
+	if branch, detached, err := git.Head(); err == nil {
+		report.Git.Branch = branch
+		report.Git.Detached = detached
+	}
+
+	if launcher, _, err := browser.Launcher(); err != nil {
+		report.Browser.Error = err.Error()
+	} else {
+		report.Browser.Launcher = launcher
+		if path, err := exec.LookPath(launcher); err != nil {
+			report.Browser.Error = launcher + " not found on PATH"
+		} else {
+			report.Browser.Path = path
+		}
+	}
+	return report
+}
+
+func init() {
+	doctorCmd.Flags().StringVarP(&doctorOutput, "output", "o", "", "Output format: text or json")
+	rootCmd.AddCommand(doctorCmd)
+}
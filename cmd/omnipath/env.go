@@ -0,0 +1,71 @@
+package omnipath
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/adammpkins/OmniPath/internal/detect"
+	"github.com/adammpkins/OmniPath/internal/tui"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	envService  string
+	envFile     string
+	envOverride bool
+)
+
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Print the environment variables 'omnipath run' adds when launching services",
+	Long: `Print the environment variables 'omnipath run' adds to the current
+environment when launching services, as KEY=value lines that can be passed
+to eval. Interactive services get variables describing the terminal they
+run on; the env file is applied to every service.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Without --service, show what a generic interactive service gets.
+		s := tui.Service{Name: "service", Interactive: true}
+		if envService != "" {
+			selected, err := servicesByName(toTUIServices(detect.GetServices()), []string{envService})
+			if err != nil {
+				return err
+			}
+			s = selected[0]
+		}
+
+		var env []string
+		if s.Interactive {
+			env = buildServiceEnv(s)
+		}
+		dotEnv, err := loadDotEnv(envFile, cmd.Flags().Changed("env-file"), envOverride)
+		if err != nil {
+			return fmt.Errorf("loading env file: %w", err)
+		}
+		for _, kv := range append(env, dotEnv...) {
+			key, value, _ := strings.Cut(kv, "=")
+			fmt.Printf("%s=%s\n", key, envQuote(value))
+		}
+		return nil
+	},
+}
+
+// envSafeValue matches values that need no quoting in a shell assignment.
+var envSafeValue = regexp.MustCompile(`^[A-Za-z0-9_./:,+=@%-]*$`)
+
+// envQuote single-quotes value when the shell would otherwise split or
+// expand it.
+func envQuote(value string) string {
+	if envSafeValue.MatchString(value) {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+func init() {
+	envCmd.Flags().StringVar(&envService, "service", "", "Show the environment of the named detected service")
+	envCmd.Flags().StringVar(&envFile, "env-file", ".env", "Env file whose variables are passed to services")
+	envCmd.Flags().BoolVar(&envOverride, "env-override", false, "Include env file variables even if they are already set in the environment")
+	rootCmd.AddCommand(envCmd)
+}
@@ -0,0 +1,49 @@
+package omnipath
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/adammpkins/OmniPath/internal/links"
+	"github.com/adammpkins/OmniPath/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var openCmd = &cobra.Command{
+	Use:   "open [alias]",
+	Short: "Open a project link (CI, staging, prod, ...) from .omnipath/links.yaml",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectLinks, err := links.Load()
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("no links configured; add aliases and URLs to %s, e.g. \"ci: https://...\"", links.File)
+		}
+		if err != nil {
+			return fmt.Errorf("loading links: %w", err)
+		}
+		if len(projectLinks) == 0 {
+			return fmt.Errorf("no links configured in %s", links.File)
+		}
+
+		var selected links.Link
+		if len(args) == 1 {
+			link, ok := links.Find(projectLinks, args[0])
+			if !ok {
+				return fmt.Errorf("no link named %q in %s", args[0], links.File)
+			}
+			selected = link
+		} else {
+			selected, err = tui.SelectLink(projectLinks)
+			if err != nil {
+				return fmt.Errorf("selecting link: %w", err)
+			}
+		}
+
+		return openURL(selected.Alias, selected.URL)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(openCmd)
+}
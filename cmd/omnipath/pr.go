@@ -0,0 +1,57 @@
+package omnipath
+
+import (
+	"fmt"
+
+	"github.com/adammpkins/OmniPath/internal/git"
+
+	"github.com/spf13/cobra"
+)
+
+var prBase string
+
+var prCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "Open the page for creating a pull request from the current branch",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		remote, err := git.GetRemote()
+		if err != nil {
+			return fmt.Errorf("retrieving git remote: %w", err)
+		}
+
+		repoURL, err := git.ParseRemoteURL(remote)
+		if err != nil {
+			return fmt.Errorf("parsing remote URL: %w", err)
+		}
+
+		branch, detached, err := git.Head()
+		if err != nil {
+			return fmt.Errorf("retrieving current branch: %w", err)
+		}
+		if detached {
+			return fmt.Errorf("HEAD is detached at %s; check out a branch first", branch)
+		}
+
+		base := prBase
+		if base == "" {
+			if base, err = git.DefaultBranch(); err != nil {
+				return fmt.Errorf("retrieving default branch: %w (pass --base to choose one)", err)
+			}
+		}
+		if base == branch {
+			return fmt.Errorf("you're on %s, the base branch; check out the branch you want to open a pull request for", branch)
+		}
+
+		url, err := git.NewPullRequestURL(repoURL, base, branch)
+		if err != nil {
+			return fmt.Errorf("building pull request URL: %w", err)
+		}
+
+		return openURL("pull request for "+branch, url)
+	},
+}
+
+func init() {
+	prCmd.Flags().StringVar(&prBase, "base", "", "Branch to merge into (defaults to the remote's default branch)")
+	rootCmd.AddCommand(prCmd)
+}
@@ -1,32 +1,70 @@
 package omnipath
 
 import (
+	"fmt"
 	"log"
+	"os"
 
 	"github.com/adammpkins/OmniPath/internal/browser"
+	"github.com/adammpkins/OmniPath/internal/config"
 	"github.com/adammpkins/OmniPath/internal/readme"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	readmeOffline  bool
+	readmePrintCSS bool
+	readmeEmoji    bool
+	readmeExport   string
 )
 
 var readmeCmd = &cobra.Command{
 	Use:   "readme",
 	Short: "Serve README.md as HTML with dark styling",
-	Run: func(cmd *cobra.Command, args []string) {
-		port := "8080"
+	RunE: func(cmd *cobra.Command, args []string) error {
+		port := viper.GetString(config.KeyReadmePort)
 		readmePath := "README.md"
+		opts := readme.Options{
+			Offline:  readmeOffline,
+			NoEmoji:  !readmeEmoji,
+			PrintCSS: readmePrintCSS,
+		}
 
-		go func() {
-			url := "http://localhost:" + port
-			if err := browser.OpenURL(url); err != nil {
-				log.Fatalf("Failed to open browser: %v", err)
+		if readmeExport != "" {
+			page, err := readme.RenderReadme(readmePath, opts)
+			if err != nil {
+				return err
 			}
-		}()
+			if err := os.WriteFile(readmeExport, page, 0o644); err != nil {
+				return fmt.Errorf("exporting README: %w", err)
+			}
+			fmt.Printf("Wrote %s\n", readmeExport)
+			return nil
+		}
+
+		if viper.GetBool(config.KeyAutoOpen) && !viper.GetBool(config.KeyPrintMode) {
+			go func() {
+				url := "http://localhost:" + port
+				if err := browser.OpenURL(url); err != nil {
+					log.Printf("Failed to open browser: %v; visit %s instead", err, url)
+				}
+			}()
+		}
 
-		readme.ServeReadmeAsHTML(readmePath, port)
+		return readme.ServeReadmeAsHTML(readmePath, port, opts)
 	},
 }
 
 func init() {
+	readmeCmd.Flags().String("port", "8080", "Port to serve the README on (config: readme_port)")
+	readmeCmd.Flags().Bool("open", true, "Open the rendered README in the browser (config: auto_open)")
+	readmeCmd.Flags().BoolVar(&readmeOffline, "offline", false, "Make the page self-contained, highlighting code while rendering instead of loading assets from CDNs")
+	readmeCmd.Flags().BoolVar(&readmeEmoji, "emoji", true, "Render shortcodes such as :rocket: as emoji, as GitHub does")
+	readmeCmd.Flags().BoolVar(&readmePrintCSS, "print-css", false, "Use the light print stylesheet on screen too, to preview printing or saving as PDF")
+	readmeCmd.Flags().StringVar(&readmeExport, "export", "", "Write the rendered page to this file instead of serving it")
+	_ = viper.BindPFlag(config.KeyReadmePort, readmeCmd.Flags().Lookup("port"))
+	_ = viper.BindPFlag(config.KeyAutoOpen, readmeCmd.Flags().Lookup("open"))
 	rootCmd.AddCommand(readmeCmd)
 }
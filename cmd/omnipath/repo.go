@@ -2,9 +2,7 @@ package omnipath
 
 import (
 	"fmt"
-	"log"
 
-	"github.com/adammpkins/OmniPath/internal/browser"
 	"github.com/adammpkins/OmniPath/internal/git"
 
 	"github.com/spf13/cobra"
@@ -13,21 +11,22 @@ import (
 var repoCmd = &cobra.Command{
 	Use:   "repo",
 	Short: "Open the GitHub repository in a browser",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		remote, err := git.GetRemote()
 		if err != nil {
-			log.Fatalf("Error retrieving git remote: %v", err)
+			return fmt.Errorf("retrieving git remote: %w", err)
 		}
 
 		url, err := git.ParseRemoteURL(remote)
 		if err != nil {
-			log.Fatalf("Error parsing remote URL: %v", err)
+			return fmt.Errorf("parsing remote URL: %w", err)
 		}
 
-		fmt.Printf("Opening %s in your browser...\n", url)
-		if err := browser.OpenURL(url); err != nil {
-			log.Fatalf("Failed to open browser: %v", err)
+		what := "repository"
+		if branch, err := git.CurrentBranch(); err == nil {
+			what += " (on " + branch + ")"
 		}
+		return openURL(what, url)
 	},
 }
 
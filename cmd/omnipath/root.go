@@ -1,23 +1,118 @@
 package omnipath
 
 import (
+	"errors"
 	"fmt"
-	"github.com/spf13/cobra"
+	"log/slog"
 	"os"
+
+	"github.com/adammpkins/OmniPath/internal/browser"
+	"github.com/adammpkins/OmniPath/internal/config"
+	"github.com/adammpkins/OmniPath/internal/detect"
+	"github.com/adammpkins/OmniPath/internal/docs"
+	"github.com/adammpkins/OmniPath/internal/tui"
+	"github.com/adammpkins/OmniPath/internal/tui/multiplexer"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
+// Exit codes, so scripts can tell common failures apart.
+const (
+	exitFailure        = 1 // Any other error.
+	exitNoDependencies = 2 // No known dependencies were detected.
+	exitBrowserFailed  = 3 // The browser couldn't be launched.
+)
+
+// debug turns on debug logging, such as of paths skipped while scanning.
+var debug bool
+
 var rootCmd = &cobra.Command{
 	Use:   "omnipath",
 	Short: "OmniPath - A smart directory-based automation tool",
 	Long:  "OmniPath helps navigate projects, open repositories, serve local docs, open dependency documentation, and auto-run projects based on context.",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Omnipath CLI - Use a subcommand. Try 'omnipath repo', 'omnipath docs', 'omnipath depdocs' or 'omnipath run'")
+	// Usage is printed for mistakes in the command line, which cobra reports
+	// before running the command, but not for errors the command returns.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		if debug {
+			slog.SetLogLoggerLevel(slog.LevelDebug)
+		}
+		if err := config.Load(); err != nil {
+			return err
+		}
+		browser.Command = viper.GetString(config.KeyBrowser)
+		detect.ScanIgnore = viper.GetStringSlice(config.KeyScanIgnore)
+		if err := tui.SetSelectKeys(viper.GetStringMapStringSlice(config.KeySelectKeys)); err != nil {
+			return fmt.Errorf("%s in config: %w", config.KeySelectKeys, err)
+		}
+		if err := multiplexer.SetKeys(viper.GetStringMapStringSlice(config.KeyMultiplexerKeys)); err != nil {
+			return fmt.Errorf("%s in config: %w", config.KeyMultiplexerKeys, err)
+		}
+		return nil
 	},
+	SilenceErrors: true,
+	// Without a subcommand, the dashboard is the home screen. Scripts and
+	// pipes get a hint instead, since they can't drive it.
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if isInteractive() {
+			return runDashboard(cmd, args)
+		}
+		fmt.Println("Omnipath CLI - Use a subcommand. Try 'omnipath dashboard', 'omnipath repo', 'omnipath docs', 'omnipath depdocs' or 'omnipath run'")
+		return nil
+	},
+}
+
+// openURL opens url in the browser after telling the user what is being
+// opened. In print mode only the URL is printed, so it can be piped elsewhere.
+func openURL(what, url string) error {
+	if viper.GetBool(config.KeyPrintMode) {
+		fmt.Println(url)
+		return nil
+	}
+	fmt.Printf("Opening %s: %s\n", what, url)
+	if err := browser.OpenURL(url); err != nil {
+		return browserError(err)
+	}
+	return nil
+}
+
+// exitCodeError attaches a specific exit code to an error.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string { return e.err.Error() }
+func (e *exitCodeError) Unwrap() error { return e.err }
+
+// browserError reports that the browser couldn't be opened.
+func browserError(err error) error {
+	return &exitCodeError{code: exitBrowserFailed, err: fmt.Errorf("failed to open browser: %w", err)}
+}
+
+// exitCode returns the process exit code for an error returned by a command.
+func exitCode(err error) int {
+	var coded *exitCodeError
+	switch {
+	case errors.As(err, &coded):
+		return coded.code
+	case errors.Is(err, docs.ErrNoDependencies):
+		return exitNoDependencies
+	}
+	return exitFailure
+}
+
+func init() {
+	rootCmd.PersistentFlags().String("browser", "", "Command used to open URLs instead of the default browser (config: browser)")
+	rootCmd.PersistentFlags().Bool("print", false, "Print URLs instead of opening them in the browser (config: print_mode)")
+	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Log debugging details, such as paths skipped while scanning")
+	_ = viper.BindPFlag(config.KeyBrowser, rootCmd.PersistentFlags().Lookup("browser"))
+	_ = viper.BindPFlag(config.KeyPrintMode, rootCmd.PersistentFlags().Lookup("print"))
 }
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(exitCode(err))
 	}
 }
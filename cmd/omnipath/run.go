@@ -2,57 +2,178 @@ package omnipath
 
 import (
 	"bufio"
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"log"
 	"os"
 	"os/exec"
+	"os/signal"
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
+	"github.com/adammpkins/OmniPath/internal/browser"
 	detect "github.com/adammpkins/OmniPath/internal/detect"
+	"github.com/adammpkins/OmniPath/internal/envfile"
+	"github.com/adammpkins/OmniPath/internal/output"
+	"github.com/adammpkins/OmniPath/internal/ports"
+	"github.com/adammpkins/OmniPath/internal/proc"
+	"github.com/adammpkins/OmniPath/internal/runlog"
 	"github.com/adammpkins/OmniPath/internal/tui"
 	"github.com/adammpkins/OmniPath/internal/tui/multiplexer"
 	"github.com/spf13/cobra"
 )
 
+var (
+	runScrollback    int
+	runServiceNames  []string
+	runList          bool
+	runRecursive     bool
+	runTests         bool
+	runMigrate       bool
+	runFramework     string
+	runDefault       bool
+	runPrefix        bool
+	runKeepOpen      bool
+	runDryRun        bool
+	runEnvFile       string
+	runEnvOverride   bool
+	runLogDir        string
+	runLogTimestamps bool
+	runGracePeriod   time.Duration
+	runTimeout       time.Duration
+	runHealthCheck   bool
+	runHealthTimeout time.Duration
+	runOpenOnReady   bool
+	runWatch         bool
+	runAttach        bool
+	runOutput        string
+
+	// runDotEnv holds the variables loaded from the env file that should be
+	// passed to every service.
+	runDotEnv []string
+)
+
 var runCmd = &cobra.Command{
 	Use:   "run",
 	Short: "Run selected service(s) interactively (if interactive) or in foreground (if non-interactive)",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get services from detect.
-		detectServices := detect.GetServices()
-		if len(detectServices) == 0 {
-			log.Println("No run commands detected. Please try running the project manually.")
-			return
+		var framework detect.Detector
+		if runFramework != "" {
+			d, err := detect.FindDetector(runFramework)
+			if err != nil {
+				return err
+			}
+			if runRecursive && !detect.DetectedInWorkspace(d) {
+				return fmt.Errorf("no %s project detected in this directory or its workspace members", d.Name())
+			}
+			if !runRecursive && !d.Detect(".") {
+				return fmt.Errorf("no %s project detected in this directory", d.Name())
+			}
+			framework = d
+		}
+		detectServices := appServices(framework)
+		if runTests {
+			detectServices = append(detectServices, testServices(framework)...)
+		}
+		if runMigrate {
+			detectServices = append(detectServices, migrationServices(framework)...)
+		}
+		allServices := toTUIServices(detectServices)
+
+		if runList || runOutput != "" {
+			format, err := output.ParseFormat(runOutput)
+			if err != nil {
+				return err
+			}
+			if format == output.FormatJSON {
+				// An empty list rather than null when nothing was detected.
+				services := append([]detect.Service{}, detectServices...)
+				return output.JSON(os.Stdout, output.Services{SchemaVersion: output.SchemaVersion, Services: services})
+			}
+			for _, s := range allServices {
+				fmt.Println(s.Name)
+			}
+			return nil
 		}
 
-		// Convert detect.Service to tui.Service.
-		var allServices []tui.Service
-		for _, ds := range detectServices {
-			allServices = append(allServices, tui.Service{
-				Name:        ds.Name,
-				Command:     ds.Command,
-				Interactive: ds.Interactive,
-			})
+		if len(detectServices) == 0 {
+			log.Println("No run commands detected. Please try running the project manually.")
+			return nil
 		}
 
 		var selectedServices []tui.Service
-		// If more than one service is available, prompt for selection.
-		if len(allServices) > 1 {
+		// Services named on the command line skip the selection prompt.
+		if len(runServiceNames) > 0 {
+			selected, err := servicesByName(allServices, runServiceNames)
+			if err != nil {
+				return err
+			}
+			selectedServices = selected
+		} else if runDefault {
+			// Services are sorted by priority, so the first is the best default.
+			log.Printf("Running the default service: %s", allServices[0].Name)
+			selectedServices = allServices[:1]
+		} else if len(allServices) > 1 {
+			// If more than one service is available, prompt for selection.
 			selected, err := tui.RunMultiSelect(allServices)
 			if err != nil {
-				log.Fatalf("Error selecting service: %v", err)
+				return fmt.Errorf("selecting service: %w", err)
 			}
 			if len(selected) == 0 {
 				log.Println("No service selected.")
-				return
+				return nil
 			}
 			selectedServices = selected
 		} else {
 			selectedServices = []tui.Service{allServices[0]}
 		}
 
+		dotEnv, err := loadDotEnv(runEnvFile, cmd.Flags().Changed("env-file"), runEnvOverride)
+		if err != nil {
+			return fmt.Errorf("loading env file: %w", err)
+		}
+		runDotEnv = dotEnv
+
+		selectedServices = checkToolVersions(selectedServices)
+
+		if runDryRun {
+			printDryRun(selectedServices)
+			return nil
+		}
+
+		if !checkPorts(selectedServices) {
+			log.Println("Aborted.")
+			return nil
+		}
+
+		// Make sure no service outlives OmniPath, whether it exits normally,
+		// panics, or is interrupted while a foreground service is running.
+		defer proc.Cleanup(runGracePeriod)
+		stopCleanup := handleSignals(runGracePeriod)
+		defer stopCleanup()
+
+		if runAttach {
+			if runWatch {
+				return errors.New("--attach and --watch can't be combined")
+			}
+			if len(selectedServices) != 1 {
+				return errors.New("--attach hands the terminal to a single service; select only one")
+			}
+			return attachService(selectedServices[0])
+		}
+
+		if runWatch {
+			if len(selectedServices) != 1 {
+				return errors.New("--watch restarts a single service; select only one")
+			}
+			return watchService(selectedServices[0])
+		}
+
 		// Split selected services into interactive and non-interactive.
 		var interactiveServices []tui.Service
 		var nonInteractiveServices []tui.Service
@@ -67,135 +188,503 @@ var runCmd = &cobra.Command{
 		// Run non-interactive services in the foreground.
 		for _, s := range nonInteractiveServices {
 			log.Printf("Launching non-interactive service %s: %s\n", s.Name, s.Command)
-			c := exec.Command("sh", "-c", s.Command)
+			var ctx context.Context
+			var cancel context.CancelFunc
+			if runTimeout > 0 {
+				ctx, cancel = context.WithTimeout(context.Background(), runTimeout)
+			} else {
+				ctx, cancel = context.WithCancel(context.Background())
+			}
+			c := proc.CommandContext(ctx, s.Command)
 			// Attach standard input/output so the command's output is visible.
 			c.Stdout = os.Stdout
 			c.Stderr = os.Stderr
 			c.Stdin = os.Stdin
-			c.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
-			if err := c.Run(); err != nil {
+			c.Env = serviceEnv(s)
+			c.Dir = s.Dir
+			logFile := openServiceLog(s)
+			if logFile != nil {
+				c.Stdout = io.MultiWriter(os.Stdout, logFile)
+				c.Stderr = io.MultiWriter(os.Stderr, logFile)
+			}
+			err := runForeground(c)
+			timedOut := ctx.Err() == context.DeadlineExceeded
+			cancel()
+			if timedOut {
+				log.Printf("%s timed out after %v and was stopped", s.Name, runTimeout)
+			} else if err != nil {
 				log.Printf("Error running %s: %v", s.Name, err)
 			}
+			if logFile != nil {
+				logFile.Close()
+			}
 		}
 
 		// Launch interactive services using the multiplexer.
 		if len(interactiveServices) > 0 {
-			var sessions []*tui.Session // Use pointers for live updates.
-			var wg sync.WaitGroup
-			var mu sync.Mutex
-
-			for _, s := range interactiveServices {
-				wg.Add(1)
-				go func(s tui.Service) {
-					defer wg.Done()
-					log.Printf("Launching interactive service %s: %s\n", s.Name, s.Command)
-					c := exec.Command("sh", "-c", s.Command)
-					c.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
-
-					// Enhanced environment variables for better color support
-					env := append(os.Environ(),
-						"FORCE_COLOR=1",
-						"TERM=xterm-256color",
-						"COLORTERM=truecolor",
-						"COMPOSE_FORCE_COLOR=1",
-						"DOCKER_COLOR=1")
-
-					// For Laravel Sail specifically, add more Docker-related vars
-					if strings.Contains(strings.ToLower(s.Name), "sail") {
-						env = append(env,
-							"DOCKER_BUILDKIT=1",
-							"LS_COLORS=rs=0:di=01;34:ln=01;36:mh=00:pi=40;33:so=01;35:do=01;35:bd=40;33;01:cd=40;33;01:or=40;31;01:mi=00:su=37;41:sg=30;43:ca=00:tw=30;42:ow=34;42:st=37;44:ex=01;32:*.tar=01;31:*.tgz=01;31:*.arc=01;31:*.arj=01;31:*.taz=01;31:*.lha=01;31:*.lz4=01;31:*.lzh=01;31:*.lzma=01;31:*.tlz=01;31:*.txz=01;31:*.tzo=01;31:*.t7z=01;31:*.zip=01;31:*.z=01;31:*.dz=01;31:*.gz=01;31:*.lrz=01;31:*.lz=01;31:*.lzo=01;31:*.xz=01;31:*.zst=01;31:*.tzst=01;31:*.bz2=01;31:*.bz=01;31:*.tbz=01;31:*.tbz2=01;31:*.tz=01;31:*.deb=01;31:*.rpm=01;31:*.jar=01;31:*.war=01;31:*.ear=01;31:*.sar=01;31:*.rar=01;31:*.alz=01;31:*.ace=01;31:*.zoo=01;31:*.cpio=01;31:*.7z=01;31:*.rz=01;31:*.cab=01;31:*.wim=01;31:*.swm=01;31:*.dwm=01;31:*.esd=01;31:*.avif=01;35:*.jpg=01;35:*.jpeg=01;35:*.mjpg=01;35:*.mjpeg=01;35:*.gif=01;35:*.bmp=01;35:*.pbm=01;35:*.pgm=01;35:*.ppm=01;35:*.tga=01;35:*.xbm=01;35:*.xpm=01;35:*.tif=01;35:*.tiff=01;35:*.png=01;35:*.svg=01;35:*.svgz=01;35:*.mng=01;35:*.pcx=01;35:*.mov=01;35:*.mpg=01;35:*.mpeg=01;35:*.m2v=01;35:*.mkv=01;35:*.webm=01;35:*.webp=01;35:*.ogm=01;35:*.mp4=01;35:*.m4v=01;35:*.mp4v=01;35:*.vob=01;35:*.qt=01;35:*.nuv=01;35:*.wmv=01;35:*.asf=01;35:*.rm=01;35:*.rmvb=01;35:*.flc=01;35:*.avi=01;35:*.fli=01;35:*.flv=01;35:*.gl=01;35:*.dl=01;35:*.xcf=01;35:*.xwd=01;35:*.yuv=01;35:*.cgm=01;35:*.emf=01;35:*.ogv=01;35:*.ogx=01;35:*.aac=00;36:*.au=00;36:*.flac=00;36:*.m4a=00;36:*.mid=00;36:*.midi=00;36:*.mka=00;36:*.mp3=00;36:*.mpc=00;36:*.ogg=00;36:*.ra=00;36:*.wav=00;36:*.oga=00;36:*.opus=00;36:*.spx=00;36:*.xspf=00;36:",
-							"CLICOLOR=1",
-							"CLICOLOR_FORCE=1")
-					}
-					c.Env = env
-
-					stdoutPipe, err := c.StdoutPipe()
-					if err != nil {
-						log.Printf("Error obtaining stdout for %s: %v", s.Name, err)
-						return
-					}
-					stderrPipe, err := c.StderrPipe()
-					if err != nil {
-						log.Printf("Error obtaining stderr for %s: %v", s.Name, err)
-						return
-					}
-					stdinPipe, err := c.StdinPipe()
-					if err != nil {
-						log.Printf("Error obtaining stdin for %s: %v", s.Name, err)
-						return
-					}
-
-					if err := c.Start(); err != nil {
-						log.Printf("Error starting %s: %v", s.Name, err)
-						return
-					}
-
-					session := &tui.Session{
-						Name:   s.Name,
-						Stdin:  stdinPipe,
-						Output: "",
-						Cmd:    c,
-					}
-
-					// Read stdout concurrently.
-					go func() {
-						reader := bufio.NewReader(stdoutPipe)
-						buffer := make([]byte, 1024)
-						for {
-							n, err := reader.Read(buffer)
-							if err != nil {
-								if err != io.EOF {
-									log.Printf("Error reading stdout for %s: %v", s.Name, err)
-								}
-								break
-							}
-							if n > 0 {
-								mu.Lock()
-								session.Output += string(buffer[:n])
-								mu.Unlock()
-							}
-						}
-					}()
-
-					// Read stderr concurrently.
-					go func() {
-						reader := bufio.NewReader(stderrPipe)
-						buffer := make([]byte, 1024)
-						for {
-							n, err := reader.Read(buffer)
-							if err != nil {
-								if err != io.EOF {
-									log.Printf("Error reading stderr for %s: %v", s.Name, err)
-								}
-								break
-							}
-							if n > 0 {
-								mu.Lock()
-								session.Output += string(buffer[:n])
-								mu.Unlock()
-							}
-						}
-					}()
-
-					mu.Lock()
-					sessions = append(sessions, session)
-					mu.Unlock()
-				}(s)
-			}
-			wg.Wait()
-
+			sessions, outputs, closeLogs := startSessions(interactiveServices)
+			defer closeLogs()
 			if len(sessions) == 0 {
-				log.Fatalf("No interactive sessions available due to errors starting processes.")
+				return errors.New("no interactive sessions available due to errors starting processes")
 			}
 
-			if err := multiplexer.RunMultiplexer(sessions); err != nil {
-				log.Fatalf("Error running multiplexer: %v", err)
+			restart := func(session *tui.Session) error {
+				out := outputs[session]
+				fmt.Fprintf(out, "\n--- restarting %s: %s ---\n", session.Name, session.Service.Command)
+				return startSession(session, out)
+			}
+			if err := multiplexer.RunMultiplexer(sessions, multiplexer.Options{Restart: restart, KeepOpen: runKeepOpen, Prefix: runPrefix, GracePeriod: runGracePeriod, Terminal: sessionsOnTerminal}); err != nil {
+				return fmt.Errorf("running multiplexer: %w", err)
 			}
 		}
+		return nil
 	},
 }
 
+// appServices returns the services run offers: those of framework alone when
+// it isn't nil, and those of workspace members too with --recursive.
+func appServices(framework detect.Detector) []detect.Service {
+	switch {
+	case framework != nil && runRecursive:
+		return detect.GetWorkspaceServicesFrom(framework)
+	case framework != nil:
+		return detect.GetServicesFrom(framework)
+	case runRecursive:
+		return detect.GetWorkspaceServices()
+	}
+	return detect.GetServices()
+}
+
+// testServices returns the test services --tests offers, chosen like
+// appServices.
+func testServices(framework detect.Detector) []detect.Service {
+	switch {
+	case framework != nil && runRecursive:
+		return detect.GetWorkspaceTestServicesFrom(framework)
+	case framework != nil:
+		return detect.GetTestServicesFrom(framework)
+	case runRecursive:
+		return detect.GetWorkspaceTestServices()
+	}
+	return detect.GetTestServices()
+}
+
+// migrationServices returns the migration services --migrate offers, chosen
+// like appServices.
+func migrationServices(framework detect.Detector) []detect.Service {
+	switch {
+	case framework != nil && runRecursive:
+		return detect.GetWorkspaceMigrationServicesFrom(framework)
+	case framework != nil:
+		return detect.GetMigrationServicesFrom(framework)
+	case runRecursive:
+		return detect.GetWorkspaceMigrationServices()
+	}
+	return detect.GetMigrationServices()
+}
+
+// toTUIServices converts detected services into the form the run UI uses.
+func toTUIServices(detected []detect.Service) []tui.Service {
+	var services []tui.Service
+	for _, ds := range detected {
+		services = append(services, tui.Service{
+			Name:        ds.Name,
+			Command:     ds.Command,
+			Interactive: ds.Interactive,
+			Dir:         ds.Dir,
+		})
+	}
+	return services
+}
+
+// loadDotEnv reads the env file at path and returns the KEY=value pairs to add
+// to each service's environment. Variables already set in the real
+// environment are skipped unless override is set. A missing file is only an
+// error when the path was given explicitly.
+func loadDotEnv(path string, explicit, override bool) ([]string, error) {
+	vars, err := envfile.Load(path)
+	if os.IsNotExist(err) && !explicit {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var env []string
+	for _, v := range vars {
+		if _, set := os.LookupEnv(v.Key); set && !override {
+			continue
+		}
+		env = append(env, v.String())
+	}
+	return env, nil
+}
+
+// serviceEnv returns the complete environment for a service's process:
+// the current environment, OmniPath's tweaks for interactive services, and
+// finally the env file so its values win over the tweaks.
+func serviceEnv(s tui.Service) []string {
+	env := os.Environ()
+	if s.Interactive {
+		env = append(env, buildServiceEnv(s)...)
+	}
+	return append(env, runDotEnv...)
+}
+
+// buildServiceEnv returns the environment variables added on top of the
+// current environment when launching an interactive service.
+func buildServiceEnv(s tui.Service) []string {
+	// Describe the terminal the multiplexer emulates. Without a
+	// pseudo-terminal, services must also be told to use colors anyway.
+	env := []string{
+		"TERM=xterm-256color",
+		"COLORTERM=truecolor",
+	}
+	if !sessionsOnTerminal {
+		env = append(env,
+			"FORCE_COLOR=1",
+			"COMPOSE_FORCE_COLOR=1",
+			"DOCKER_COLOR=1")
+	}
+
+	// For Laravel Sail specifically, add more Docker-related vars
+	if isSail(s.Name) {
+		env = append(env,
+			"DOCKER_BUILDKIT=1",
+			"LS_COLORS=rs=0:di=01;34:ln=01;36:mh=00:pi=40;33:so=01;35:do=01;35:bd=40;33;01:cd=40;33;01:or=40;31;01:mi=00:su=37;41:sg=30;43:ca=00:tw=30;42:ow=34;42:st=37;44:ex=01;32:*.tar=01;31:*.tgz=01;31:*.arc=01;31:*.arj=01;31:*.taz=01;31:*.lha=01;31:*.lz4=01;31:*.lzh=01;31:*.lzma=01;31:*.tlz=01;31:*.txz=01;31:*.tzo=01;31:*.t7z=01;31:*.zip=01;31:*.z=01;31:*.dz=01;31:*.gz=01;31:*.lrz=01;31:*.lz=01;31:*.lzo=01;31:*.xz=01;31:*.zst=01;31:*.tzst=01;31:*.bz2=01;31:*.bz=01;31:*.tbz=01;31:*.tbz2=01;31:*.tz=01;31:*.deb=01;31:*.rpm=01;31:*.jar=01;31:*.war=01;31:*.ear=01;31:*.sar=01;31:*.rar=01;31:*.alz=01;31:*.ace=01;31:*.zoo=01;31:*.cpio=01;31:*.7z=01;31:*.rz=01;31:*.cab=01;31:*.wim=01;31:*.swm=01;31:*.dwm=01;31:*.esd=01;31:*.avif=01;35:*.jpg=01;35:*.jpeg=01;35:*.mjpg=01;35:*.mjpeg=01;35:*.gif=01;35:*.bmp=01;35:*.pbm=01;35:*.pgm=01;35:*.ppm=01;35:*.tga=01;35:*.xbm=01;35:*.xpm=01;35:*.tif=01;35:*.tiff=01;35:*.png=01;35:*.svg=01;35:*.svgz=01;35:*.mng=01;35:*.pcx=01;35:*.mov=01;35:*.mpg=01;35:*.mpeg=01;35:*.m2v=01;35:*.mkv=01;35:*.webm=01;35:*.webp=01;35:*.ogm=01;35:*.mp4=01;35:*.m4v=01;35:*.mp4v=01;35:*.vob=01;35:*.qt=01;35:*.nuv=01;35:*.wmv=01;35:*.asf=01;35:*.rm=01;35:*.rmvb=01;35:*.flc=01;35:*.avi=01;35:*.fli=01;35:*.flv=01;35:*.gl=01;35:*.dl=01;35:*.xcf=01;35:*.xwd=01;35:*.yuv=01;35:*.cgm=01;35:*.emf=01;35:*.ogv=01;35:*.ogx=01;35:*.aac=00;36:*.au=00;36:*.flac=00;36:*.m4a=00;36:*.mid=00;36:*.midi=00;36:*.mka=00;36:*.mp3=00;36:*.mpc=00;36:*.ogg=00;36:*.ra=00;36:*.wav=00;36:*.oga=00;36:*.opus=00;36:*.spx=00;36:*.xspf=00;36:",
+			"CLICOLOR=1",
+			"CLICOLOR_FORCE=1")
+	}
+	return env
+}
+
+// printDryRun describes how each service would be launched without starting it.
+func printDryRun(services []tui.Service) {
+	for _, s := range services {
+		mode := "non-interactive, runs in the foreground"
+		if s.Interactive {
+			mode = "interactive, runs in the multiplexer"
+		}
+		if runAttach {
+			mode = "attached to the terminal"
+		}
+		fmt.Printf("%s (%s)\n", s.Name, mode)
+		fmt.Printf("  command: %s\n", proc.CommandLine(s.Command))
+		if s.Dir != "" {
+			fmt.Printf("  dir:     %s\n", s.Dir)
+		}
+		for _, port := range ports.Extract(s.Command) {
+			fmt.Printf("  port:    %d\n", port)
+		}
+		if s.Interactive {
+			for i, kv := range buildServiceEnv(s) {
+				label := "  env:    "
+				if i > 0 {
+					label = "          "
+				}
+				fmt.Println(label, kv)
+			}
+		}
+		for _, kv := range runDotEnv {
+			key, _, _ := strings.Cut(kv, "=")
+			fmt.Printf("  env:     %s (from %s)\n", key, runEnvFile)
+		}
+		if isSail(s.Name) {
+			fmt.Println("  on quit: ./vendor/bin/sail down")
+		}
+	}
+}
+
+// checkPorts warns about any port a selected service is likely to need that
+// is already taken, and asks whether to launch anyway. It reports whether to
+// continue.
+func checkPorts(services []tui.Service) bool {
+	busy := false
+	for _, s := range services {
+		for _, port := range ports.Extract(s.Command) {
+			if ports.InUse(port) {
+				log.Printf("Port %d is already in use; %s may fail to start.", port, s.Name)
+				busy = true
+			}
+		}
+	}
+	if !busy {
+		return true
+	}
+	fmt.Print("Continue anyway? [y/N] ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// isSail reports whether a service runs Laravel Sail, which needs extra
+// environment variables and an explicit "sail down" on exit.
+func isSail(name string) bool {
+	return strings.Contains(strings.ToLower(name), "sail")
+}
+
+// servicesByName returns the services matching each of the given names, in
+// the order requested. Names match exactly, or failing that case-insensitively.
+func servicesByName(services []tui.Service, names []string) ([]tui.Service, error) {
+	var selected []tui.Service
+	for _, name := range names {
+		match := -1
+		for i, s := range services {
+			if s.Name == name {
+				match = i
+				break
+			}
+			if match == -1 && strings.EqualFold(s.Name, name) {
+				match = i
+			}
+		}
+		if match == -1 {
+			var available []string
+			for _, s := range services {
+				available = append(available, fmt.Sprintf("%q", s.Name))
+			}
+			return nil, fmt.Errorf("no detected service named %q (available: %s)", name, strings.Join(available, ", "))
+		}
+		selected = append(selected, services[match])
+	}
+	return selected, nil
+}
+
+// signalHooks holds the functions handleSignals runs before exiting, most
+// recently added first. Removed hooks are left as nil.
+var signalHooks struct {
+	mu    sync.Mutex
+	funcs []func()
+}
+
+// onSignalExit registers f to run if handleSignals exits the process, which
+// skips deferred calls, e.g. to restore a terminal put into raw mode. The
+// returned function unregisters f.
+func onSignalExit(f func()) (remove func()) {
+	signalHooks.mu.Lock()
+	defer signalHooks.mu.Unlock()
+	i := len(signalHooks.funcs)
+	signalHooks.funcs = append(signalHooks.funcs, f)
+	return func() {
+		signalHooks.mu.Lock()
+		signalHooks.funcs[i] = nil
+		signalHooks.mu.Unlock()
+	}
+}
+
+// runSignalHooks runs the functions registered with onSignalExit.
+func runSignalHooks() {
+	signalHooks.mu.Lock()
+	defer signalHooks.mu.Unlock()
+	for i := len(signalHooks.funcs) - 1; i >= 0; i-- {
+		if f := signalHooks.funcs[i]; f != nil {
+			f()
+		}
+	}
+}
+
+// handleSignals stops every tracked process group and exits when OmniPath is
+// interrupted or terminated, after running the hooks added with
+// onSignalExit. Services run in their own process groups, so they don't
+// receive the terminal's Ctrl+C themselves. The returned function uninstalls
+// the handler.
+func handleSignals(grace time.Duration) func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-signals:
+			runSignalHooks()
+			log.Printf("Received %v, stopping services...", sig)
+			proc.Cleanup(grace)
+			os.Exit(1)
+		case <-done:
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// runForeground runs a non-interactive service to completion, tracking its
+// process group so it is cleaned up if OmniPath is interrupted.
+func runForeground(c *exec.Cmd) error {
+	if err := c.Start(); err != nil {
+		return err
+	}
+	proc.Track(c)
+	defer proc.Release(c)
+	return c.Wait()
+}
+
+// openServiceLog opens the log file for a service when --log-dir is set. It
+// returns nil if logging is disabled or the file can't be opened, in which
+// case the service still runs without a log.
+func openServiceLog(s tui.Service) *runlog.Writer {
+	if runLogDir == "" {
+		return nil
+	}
+	w, err := runlog.Open(runLogDir, s.Name, runLogTimestamps)
+	if err != nil {
+		log.Printf("Error opening log file for %s: %v", s.Name, err)
+		return nil
+	}
+	return w
+}
+
+// startSessions starts a session for each service, skipping those that fail
+// to start. Sessions are started one after another, so the slice is complete,
+// and in the order the services were selected, before the multiplexer runs.
+// outputs maps each session to where its process output is written: the
+// session itself, plus its log file if enabled. closeLogs closes those log
+// files once the sessions are done.
+func startSessions(services []tui.Service) (sessions []*tui.Session, outputs map[*tui.Session]io.Writer, closeLogs func()) {
+	outputs = make(map[*tui.Session]io.Writer)
+	var logs []*runlog.Writer
+	for _, s := range services {
+		log.Printf("Launching interactive service %s: %s\n", s.Name, s.Command)
+		session := tui.NewSession(s, runScrollback)
+		var out io.Writer = session
+		if logFile := openServiceLog(s); logFile != nil {
+			logs = append(logs, logFile)
+			out = io.MultiWriter(session, logFile)
+		}
+		if err := startSession(session, out); err != nil {
+			log.Printf("Error starting %s: %v", s.Name, err)
+			continue
+		}
+		sessions = append(sessions, session)
+		outputs[session] = out
+	}
+	return sessions, outputs, func() {
+		for _, f := range logs {
+			f.Close()
+		}
+	}
+}
+
+// startSession launches the session's service, on a pseudo-terminal where
+// available, and wires the session's input into it and its output into out,
+// which is normally the session itself. The session's exit status is
+// recorded once the process finishes, so it can later be restarted by
+// calling startSession again.
+func startSession(session *tui.Session, out io.Writer) error {
+	s := session.Service
+	c := proc.Command(s.Command)
+
+	c.Env = serviceEnv(s)
+	c.Dir = s.Dir
+
+	stdin, output, err := startProcess(c)
+	if err != nil {
+		return err
+	}
+
+	session.Attach(c, stdin)
+	proc.Track(c)
+	if runHealthCheck || runOpenOnReady {
+		go checkHealth(session)
+	}
+
+	// Copy the output until every process holding the terminal or pipe has
+	// closed it, then record the exit status.
+	go func() {
+		defer tui.RestoreOnPanic()
+		buffer := make([]byte, 4096)
+		for {
+			n, err := output.Read(buffer)
+			if n > 0 {
+				out.Write(buffer[:n])
+			}
+			if err != nil {
+				// A pseudo-terminal reports EIO rather than EOF once closed.
+				if err != io.EOF && !errors.Is(err, syscall.EIO) {
+					log.Printf("Error reading output of %s: %v", s.Name, err)
+				}
+				break
+			}
+		}
+		_ = c.Wait()
+		output.Close()
+		proc.Release(c)
+		session.SetExited(c.ProcessState.ExitCode())
+	}()
+	return nil
+}
+
+// openedOnReady records the sessions whose app --open-on-ready has opened, so
+// restarting a service doesn't open another tab.
+var openedOnReady sync.Map
+
+// checkHealth waits for the session's service to listen on the port its
+// command is likely to use, for up to --health-timeout. With --health-check
+// it records the outcome so the multiplexer shows it; with --open-on-ready
+// it opens the app in the browser once it listens. Services without a known
+// port aren't checked.
+func checkHealth(session *tui.Session) {
+	defer tui.RestoreOnPanic()
+	found := ports.Extract(session.Service.Command)
+	if len(found) == 0 {
+		return
+	}
+	setHealth := func(h tui.Health) {
+		if runHealthCheck {
+			session.SetHealth(h)
+		}
+	}
+	setHealth(tui.HealthStarting)
+	ctx, cancel := context.WithTimeout(context.Background(), runHealthTimeout)
+	defer cancel()
+	// Give up as soon as the process exits; its exit status says enough.
+	done := session.Done()
+	go func() {
+		select {
+		case <-done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	if err := ports.WaitListening(ctx, found[0]); err != nil {
+		if !session.Exited() {
+			setHealth(tui.HealthFailed)
+		}
+		return
+	}
+	setHealth(tui.HealthReady)
+
+	if _, opened := openedOnReady.LoadOrStore(session, true); runOpenOnReady && !opened {
+		url := fmt.Sprintf("http://localhost:%d", found[0])
+		// The multiplexer owns the terminal, so report to the session.
+		if err := browser.OpenURL(url); err != nil {
+			fmt.Fprintf(session, "\n--- couldn't open %s: %v ---\n", url, err)
+		}
+	}
+}
+
 func init() {
+	runCmd.Flags().IntVar(&runScrollback, "scrollback", tui.DefaultScrollback, "Number of output lines to keep per interactive session")
+	runCmd.Flags().StringArrayVar(&runServiceNames, "service", nil, "Run the named service without prompting (repeatable)")
+	runCmd.Flags().BoolVar(&runDefault, "default", false, "Run the highest-priority detected service without prompting")
+	runCmd.Flags().BoolVarP(&runRecursive, "recursive", "r", false, "Also detect services in each monorepo workspace member, named <path>/<service>")
+	runCmd.Flags().StringVar(&runFramework, "framework", "", "Only offer the services of this detector, e.g. php, go, js, rust or python")
+	runCmd.Flags().BoolVar(&runTests, "tests", false, "Also offer a service running the project's tests, e.g. go test ./... or cargo test")
+	runCmd.Flags().BoolVar(&runMigrate, "migrate", false, "Also offer a service applying the project's database migrations, e.g. php artisan migrate")
+	runCmd.Flags().BoolVar(&runList, "list", false, "Print the names of the detected services and exit")
+	runCmd.Flags().StringVarP(&runOutput, "output", "o", "", "Format of --list: text or json, which lists every field; implies --list")
+	runCmd.Flags().StringVar(&runEnvFile, "env-file", ".env", "Env file whose variables are passed to services")
+	runCmd.Flags().BoolVar(&runEnvOverride, "env-override", false, "Let env file variables override ones already set in the environment")
+	runCmd.Flags().StringVar(&runLogDir, "log-dir", "", "Also write each service's output to <dir>/<service>.log")
+	runCmd.Flags().BoolVar(&runLogTimestamps, "log-timestamps", false, "Prefix each line written to --log-dir files with a timestamp")
+	runCmd.Flags().DurationVar(&runGracePeriod, "grace-period", proc.DefaultGracePeriod, "How long to wait for services to stop after each signal before escalating to SIGTERM and then SIGKILL")
+	runCmd.Flags().DurationVar(&runTimeout, "timeout", 0, "Stop each non-interactive service that runs longer than this, e.g. 30s (0 means no limit)")
+	runCmd.Flags().BoolVar(&runHealthCheck, "health-check", false, "Show whether each interactive service is listening on its port yet")
+	runCmd.Flags().DurationVar(&runHealthTimeout, "health-timeout", time.Minute, "How long --health-check and --open-on-ready wait for a service to listen before giving up")
+	runCmd.Flags().BoolVar(&runOpenOnReady, "open-on-ready", false, "Open each interactive service's http://localhost:<port> in the browser once it listens")
+	runCmd.Flags().BoolVar(&runWatch, "watch", false, "Run the selected service in the foreground and restart it when source files change")
+	runCmd.Flags().BoolVar(&runAttach, "attach", false, "Run the selected service on a real terminal of its own instead of in the multiplexer, for REPLs and other fully interactive programs")
+	runCmd.Flags().BoolVar(&runPrefix, "prefix", false, "Prefix each line of interactive output with [service] in the service's color")
+	runCmd.Flags().BoolVar(&runKeepOpen, "keep-open", false, "Keep the multiplexer open after every interactive service has exited")
+	runCmd.Flags().BoolVar(&runDryRun, "dry-run", false, "Print what would be executed for the selected services without starting them")
 	rootCmd.AddCommand(runCmd)
 }
@@ -0,0 +1,88 @@
+//go:build !windows
+
+package omnipath
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adammpkins/OmniPath/internal/tui"
+)
+
+// TestStartSessions starts fake services and reads their sessions while
+// output is still arriving, as the multiplexer does. Run it with -race.
+func TestStartSessions(t *testing.T) {
+	var services []tui.Service
+	for i := 0; i < 8; i++ {
+		services = append(services, tui.Service{
+			Name:        fmt.Sprintf("svc%d", i),
+			Command:     fmt.Sprintf("for n in 1 2 3; do echo svc%d line $n; done", i),
+			Interactive: true,
+		})
+	}
+
+	sessions, outputs, closeLogs := startSessions(services)
+	defer closeLogs()
+	if len(sessions) != len(services) {
+		t.Fatalf("started %d sessions, want %d", len(sessions), len(services))
+	}
+
+	var wg sync.WaitGroup
+	for i, sess := range sessions {
+		if sess.Name != services[i].Name {
+			t.Errorf("session %d is %s, want %s", i, sess.Name, services[i].Name)
+		}
+		if outputs[sess] == nil {
+			t.Errorf("no output recorded for %s", sess.Name)
+		}
+		wg.Add(1)
+		go func(sess *tui.Session) {
+			defer wg.Done()
+			for !sess.Exited() {
+				_ = sess.Snapshot()
+				_ = sess.Status()
+				sess.Resize(100, 30)
+			}
+		}(sess)
+	}
+
+	for _, sess := range sessions {
+		select {
+		case <-sess.Done():
+		case <-time.After(10 * time.Second):
+			t.Fatalf("%s didn't exit", sess.Name)
+		}
+	}
+	wg.Wait()
+
+	for _, sess := range sessions {
+		output := strings.Join(sess.Snapshot().Lines, "\n")
+		if want := sess.Name + " line 3"; !strings.Contains(output, want) {
+			t.Errorf("%s output %q doesn't contain %q", sess.Name, output, want)
+		}
+	}
+}
+
+func TestSignalHooks(t *testing.T) {
+	defer func() { signalHooks.funcs = nil }()
+	var ran []string
+	removeFirst := onSignalExit(func() { ran = append(ran, "first") })
+	onSignalExit(func() { ran = append(ran, "second") })
+	removeThird := onSignalExit(func() { ran = append(ran, "third") })
+	removeThird()
+
+	runSignalHooks()
+	if got := strings.Join(ran, ","); got != "second,first" {
+		t.Errorf("hooks ran as %q, want \"second,first\"", got)
+	}
+
+	ran = nil
+	removeFirst()
+	runSignalHooks()
+	if got := strings.Join(ran, ","); got != "second" {
+		t.Errorf("hooks ran as %q after removing one, want \"second\"", got)
+	}
+}
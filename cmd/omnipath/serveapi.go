@@ -0,0 +1,63 @@
+package omnipath
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/adammpkins/OmniPath/internal/api"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAPIPort int
+	serveAPIHost string
+)
+
+var serveAPICmd = &cobra.Command{
+	Use:   "serve-api",
+	Short: "Serve OmniPath's detection results as a JSON API for editor integrations",
+	Long: `Serve OmniPath's detection results for the current directory as JSON:
+
+  GET /dependencies  detected dependencies and their documentation URLs
+  GET /services      services 'omnipath run' would offer
+  GET /repo-url      browser URL of the origin remote
+
+The server only listens on localhost unless --host is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr := net.JoinHostPort(serveAPIHost, strconv.Itoa(serveAPIPort))
+		srv := &http.Server{Addr: addr, Handler: api.Handler()}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := srv.Shutdown(shutdownCtx); err != nil {
+				log.Printf("Error shutting down API server: %v", err)
+			}
+		}()
+
+		log.Printf("Serving the OmniPath API on http://%s", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("serving API: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	serveAPICmd.Flags().IntVar(&serveAPIPort, "port", 7777, "Port to listen on")
+	serveAPICmd.Flags().StringVar(&serveAPIHost, "host", "127.0.0.1", "Address to listen on")
+	rootCmd.AddCommand(serveAPICmd)
+}
@@ -0,0 +1,37 @@
+//go:build !windows
+
+package omnipath
+
+import (
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/creack/pty"
+)
+
+// sessionsOnTerminal reports whether multiplexed services run on a
+// pseudo-terminal, so they detect color support without being forced.
+const sessionsOnTerminal = true
+
+// startProcess starts c on a new pseudo-terminal. Its input and combined
+// output both go through the terminal, whose size follows the session's pane.
+func startProcess(c *exec.Cmd) (io.WriteCloser, io.ReadCloser, error) {
+	// pty.Start makes the service a session leader, which already gives it
+	// a process group of its own; asking for another would fail.
+	c.SysProcAttr.Setpgid = false
+	ptmx, err := pty.StartWithSize(c, &pty.Winsize{Cols: 80, Rows: 24})
+	if err != nil {
+		return nil, nil, err
+	}
+	return ptyInput{ptmx}, ptmx, nil
+}
+
+// ptyInput writes to a pseudo-terminal and resizes it.
+type ptyInput struct {
+	*os.File
+}
+
+func (p ptyInput) Resize(cols, rows int) error {
+	return pty.Setsize(p.File, &pty.Winsize{Cols: uint16(cols), Rows: uint16(rows)})
+}
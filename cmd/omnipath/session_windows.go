@@ -0,0 +1,39 @@
+//go:build windows
+
+package omnipath
+
+import (
+	"io"
+	"os"
+	"os/exec"
+)
+
+// sessionsOnTerminal reports whether multiplexed services run on a
+// pseudo-terminal, so they detect color support without being forced.
+const sessionsOnTerminal = false
+
+// startProcess starts c with pipes for its input and its combined output,
+// since pseudo-terminals aren't available here.
+func startProcess(c *exec.Cmd) (io.WriteCloser, io.ReadCloser, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	c.Stdout = w
+	c.Stderr = w
+	stdin, err := c.StdinPipe()
+	if err != nil {
+		r.Close()
+		w.Close()
+		return nil, nil, err
+	}
+	err = c.Start()
+	// Only the child writes to the pipe now, so reading it ends when the
+	// child and its descendants have exited.
+	w.Close()
+	if err != nil {
+		r.Close()
+		return nil, nil, err
+	}
+	return stdin, r, nil
+}
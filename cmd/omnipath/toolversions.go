@@ -0,0 +1,121 @@
+package omnipath
+
+import (
+	"log"
+	"os/exec"
+	"strings"
+
+	"github.com/adammpkins/OmniPath/internal/toolversions"
+	"github.com/adammpkins/OmniPath/internal/tui"
+)
+
+// asdfTool describes how to find the active version of a tool asdf manages.
+type asdfTool struct {
+	plugin   string   // asdf plugin name, as used in .tool-versions.
+	version  []string // Command printing the active version.
+	parse    func(output string) string
+	commands []string // Executables the tool provides.
+}
+
+// asdfTools are the tools whose pinned versions are checked before a
+// service runs.
+var asdfTools = []asdfTool{
+	{"golang", []string{"go", "env", "GOVERSION"}, trimPrefix("go"), []string{"go"}},
+	{"nodejs", []string{"node", "--version"}, trimPrefix("v"), []string{"node", "npm", "npx"}},
+	{"python", []string{"python3", "--version"}, field(1), []string{"python", "python3", "pip", "pip3"}},
+	{"ruby", []string{"ruby", "--version"}, field(1), []string{"ruby", "bundle", "gem"}},
+	{"php", []string{"php", "-r", "echo PHP_VERSION;"}, field(0), []string{"php", "composer"}},
+	{"rust", []string{"rustc", "--version"}, field(1), []string{"cargo", "rustc"}},
+}
+
+// trimPrefix parses version output such as "v20.11.0" or "go1.22.1".
+func trimPrefix(prefix string) func(string) string {
+	return func(output string) string {
+		return strings.TrimPrefix(strings.TrimSpace(output), prefix)
+	}
+}
+
+// field parses version output such as "Python 3.12.1" by taking its nth
+// word.
+func field(n int) func(string) string {
+	return func(output string) string {
+		fields := strings.Fields(output)
+		if n >= len(fields) {
+			return ""
+		}
+		return fields[n]
+	}
+}
+
+// checkToolVersions compares the tool each service runs with the version
+// pinned in .tool-versions. When they differ, the service is run through
+// "asdf exec" if asdf is installed and the command is a single program;
+// otherwise a warning is logged. It returns the services, possibly with
+// their commands wrapped.
+func checkToolVersions(services []tui.Service) []tui.Service {
+	_, asdfErr := exec.LookPath("asdf")
+	checked := make([]tui.Service, len(services))
+	for i, s := range services {
+		checked[i] = s
+		tool, ok := toolFor(s.Command)
+		if !ok {
+			continue
+		}
+		dir := s.Dir
+		if dir == "" {
+			dir = "."
+		}
+		pinned, err := toolversions.Resolve(dir)
+		if err != nil {
+			log.Printf("Reading %s: %v", toolversions.FileName, err)
+			continue
+		}
+		want := pinned[tool.plugin]
+		if len(want) == 0 {
+			continue
+		}
+		active := activeVersion(tool, s.Dir)
+		if active != "" && toolversions.Matches(want, active) {
+			continue
+		}
+		switch {
+		case asdfErr == nil && !strings.ContainsAny(s.Command, "&|;<>`$()"):
+			log.Printf("%s pins %s %s; running %s with asdf exec.", toolversions.FileName, tool.plugin, want[0], s.Name)
+			checked[i].Command = "asdf exec " + s.Command
+		case active == "":
+			log.Printf("%s pins %s %s, but its installed version couldn't be determined; %s may not use it.", toolversions.FileName, tool.plugin, want[0], s.Name)
+		default:
+			log.Printf("%s pins %s %s, but %s is active; %s may not behave as expected.", toolversions.FileName, tool.plugin, want[0], active, s.Name)
+		}
+	}
+	return checked
+}
+
+// toolFor returns the tool providing the program a command runs, if its
+// version is checked.
+func toolFor(command string) (asdfTool, bool) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return asdfTool{}, false
+	}
+	for _, tool := range asdfTools {
+		for _, name := range tool.commands {
+			if fields[0] == name {
+				return tool, true
+			}
+		}
+	}
+	return asdfTool{}, false
+}
+
+// activeVersion returns the version of tool that runs in dir, or "" if it
+// can't be determined.
+func activeVersion(tool asdfTool, dir string) string {
+	c := exec.Command(tool.version[0], tool.version[1:]...)
+	c.Dir = dir
+	out, err := c.Output()
+	if err != nil {
+		return ""
+	}
+	return tool.parse(string(out))
+}
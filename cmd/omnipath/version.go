@@ -0,0 +1,38 @@
+package omnipath
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/adammpkins/OmniPath/internal/version"
+	"github.com/spf13/cobra"
+)
+
+var versionJSON bool
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the OmniPath version, commit, and build date",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info := version.Get()
+		if versionJSON {
+			out, err := json.Marshal(info)
+			if err != nil {
+				return fmt.Errorf("encoding version: %w", err)
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+		fmt.Println("omnipath " + info.String())
+		return nil
+	},
+}
+
+func init() {
+	versionCmd.Flags().BoolVar(&versionJSON, "json", false, "Print the version information as JSON")
+	rootCmd.AddCommand(versionCmd)
+
+	// Also answer "omnipath --version" with the same single line.
+	rootCmd.Version = version.Get().String()
+	rootCmd.SetVersionTemplate("omnipath {{.Version}}\n")
+}
@@ -0,0 +1,128 @@
+package omnipath
+
+import (
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/adammpkins/OmniPath/internal/config"
+	"github.com/adammpkins/OmniPath/internal/detect"
+	"github.com/adammpkins/OmniPath/internal/proc"
+	"github.com/adammpkins/OmniPath/internal/tui"
+	"github.com/adammpkins/OmniPath/internal/watch"
+	"github.com/spf13/viper"
+)
+
+// watchLanguages maps the program a command runs to the language whose
+// watch extensions apply to it.
+var watchLanguages = map[string]string{
+	"go":      "go",
+	"air":     "go",
+	"python":  "python",
+	"python3": "python",
+	"uvicorn": "python",
+	"flask":   "python",
+	"ruby":    "ruby",
+	"bundle":  "ruby",
+	"rails":   "ruby",
+	"php":     "php",
+	"node":    "javascript",
+	"npm":     "javascript",
+	"npx":     "javascript",
+	"yarn":    "javascript",
+	"pnpm":    "javascript",
+	"bun":     "javascript",
+	"deno":    "javascript",
+	"cargo":   "rust",
+	"mix":     "elixir",
+	"elixir":  "elixir",
+}
+
+// watchExtensions returns the file extensions whose changes restart a
+// service running command, as configured for its language. Commands of no
+// known language restart for the extensions of every language.
+func watchExtensions(command string) []string {
+	fields := strings.Fields(command)
+	if len(fields) > 0 {
+		program := fields[0]
+		program = program[strings.LastIndex(program, "/")+1:]
+		if language, ok := watchLanguages[program]; ok {
+			return viper.GetStringSlice(config.KeyWatchExtensions + "." + language)
+		}
+	}
+	seen := make(map[string]bool)
+	var all []string
+	for language := range config.DefaultWatchExtensions {
+		for _, ext := range viper.GetStringSlice(config.KeyWatchExtensions + "." + language) {
+			if !seen[ext] {
+				seen[ext] = true
+				all = append(all, ext)
+			}
+		}
+	}
+	sort.Strings(all)
+	return all
+}
+
+// watchService runs a service in the foreground and restarts it whenever a
+// source file below its directory changes, until OmniPath is interrupted.
+// The previous process group is stopped, as on exit, before each restart.
+// A service that exits on its own is started again after the next change.
+func watchService(s tui.Service) error {
+	dir := s.Dir
+	if dir == "" {
+		dir = "."
+	}
+	extensions := watchExtensions(s.Command)
+	w, err := watch.New(dir, extensions, detect.Ignored, watch.DefaultDebounce)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	logFile := openServiceLog(s)
+	if logFile != nil {
+		defer logFile.Close()
+	}
+	log.Printf("Watching %s for changes to %s files; press Ctrl+C to stop.", dir, strings.Join(extensions, ", "))
+	for {
+		log.Printf("Launching %s: %s\n", s.Name, s.Command)
+		c := proc.Command(s.Command)
+		c.Stdout = os.Stdout
+		c.Stderr = os.Stderr
+		c.Stdin = os.Stdin
+		c.Env = serviceEnv(s)
+		c.Dir = s.Dir
+		if logFile != nil {
+			c.Stdout = io.MultiWriter(os.Stdout, logFile)
+			c.Stderr = io.MultiWriter(os.Stderr, logFile)
+		}
+		if err := c.Start(); err != nil {
+			return err
+		}
+		proc.Track(c)
+		exited := make(chan error, 1)
+		go func() {
+			exited <- c.Wait()
+		}()
+
+		var changed string
+		select {
+		case err := <-exited:
+			proc.Release(c)
+			if err != nil {
+				log.Printf("%s exited: %v; waiting for changes.", s.Name, err)
+			} else {
+				log.Printf("%s finished; waiting for changes.", s.Name)
+			}
+			changed = <-w.Changes()
+		case changed = <-w.Changes():
+			proc.Stop([]int{c.Process.Pid}, runGracePeriod)
+			<-exited
+			proc.Release(c)
+		}
+		log.Printf("%s changed; restarting %s.", changed, s.Name)
+	}
+}
@@ -0,0 +1,69 @@
+// Package api exposes OmniPath's project detection over HTTP as JSON, so
+// editor plugins can query it without parsing command output.
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/adammpkins/OmniPath/internal/detect"
+	"github.com/adammpkins/OmniPath/internal/docs"
+	"github.com/adammpkins/OmniPath/internal/git"
+)
+
+// Handler returns the API's routes:
+//
+//	GET /dependencies  the detected dependencies and their documentation
+//	GET /services      the services 'omnipath run' would offer
+//	GET /repo-url      the browser URL of the origin remote
+//
+// Each request detects afresh in the current directory.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /dependencies", func(w http.ResponseWriter, r *http.Request) {
+		deps, err := docs.DetectDependencies()
+		if err != nil && !errors.Is(err, docs.ErrNoDependencies) {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if deps == nil {
+			deps = []docs.DependencyDocs{}
+		}
+		docs.Sort(deps)
+		writeJSON(w, http.StatusOK, deps)
+	})
+	mux.HandleFunc("GET /services", func(w http.ResponseWriter, r *http.Request) {
+		services := detect.GetServices()
+		if services == nil {
+			services = []detect.Service{}
+		}
+		writeJSON(w, http.StatusOK, services)
+	})
+	mux.HandleFunc("GET /repo-url", func(w http.ResponseWriter, r *http.Request) {
+		remote, err := git.GetRemote()
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		url, err := git.ParseRemoteURL(remote)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"url": url})
+	})
+	return mux
+}
+
+// writeJSON writes v as the JSON response body with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes err as a JSON {"error": "..."} body.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -4,26 +4,36 @@ import (
 	"fmt"
 	"os/exec"
 	"runtime"
+	"strings"
 )
 
-// OpenURL opens the specified URL in the default web browser.
-func OpenURL(url string) error {
-	var cmd string
-	var args []string
+// Command, when set, is used to open URLs instead of the platform's default
+// browser. It may include arguments, e.g. "open -a Safari".
+var Command string
 
+// Launcher returns the command and leading arguments used to open URLs: those
+// of Command if it is set, or otherwise the platform's default.
+func Launcher() (string, []string, error) {
+	if fields := strings.Fields(Command); len(fields) > 0 {
+		return fields[0], fields[1:], nil
+	}
 	switch runtime.GOOS {
 	case "linux":
-		cmd = "xdg-open"
-		args = []string{url}
+		return "xdg-open", nil, nil
 	case "windows":
-		cmd = "rundll32"
-		args = []string{"url.dll,FileProtocolHandler", url}
+		return "rundll32", []string{"url.dll,FileProtocolHandler"}, nil
 	case "darwin":
-		cmd = "open"
-		args = []string{url}
+		return "open", nil, nil
 	default:
-		return fmt.Errorf("unsupported platform")
+		return "", nil, fmt.Errorf("unsupported platform")
 	}
+}
 
-	return exec.Command(cmd, args...).Start()
+// OpenURL opens the specified URL in the default web browser.
+func OpenURL(url string) error {
+	cmd, args, err := Launcher()
+	if err != nil {
+		return err
+	}
+	return exec.Command(cmd, append(args, url)...).Start()
 }
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+)
+
+// Keys of the settings in the config file. Commands bind their flags to these
+// keys, so a flag given on the command line overrides the config file, which
+// in turn overrides the defaults set by Load.
+const (
+	KeyBrowser    = "browser"     // Command used to open URLs instead of the platform default.
+	KeyReadmePort = "readme_port" // Port "omnipath readme" serves on.
+	KeyAutoOpen   = "auto_open"   // Whether "omnipath readme" opens the browser.
+	KeyScanIgnore = "scan_ignore" // Glob patterns of directories never scanned.
+	KeyPrintMode  = "print_mode"  // Print URLs instead of opening them.
+
+	KeyWatchExtensions = "watch_extensions" // Extensions "run --watch" watches, by language.
+
+	KeySelectKeys      = "keys.select"      // Key remappings of the service selector, by action.
+	KeyMultiplexerKeys = "keys.multiplexer" // Key remappings of the multiplexer, by action.
+)
+
+// DefaultWatchExtensions are the file extensions "run --watch" watches for
+// each language, unless the config file says otherwise.
+var DefaultWatchExtensions = map[string][]string{
+	"go":         {".go", ".mod"},
+	"python":     {".py"},
+	"ruby":       {".rb", ".erb"},
+	"php":        {".php"},
+	"javascript": {".js", ".mjs", ".cjs", ".ts", ".json"},
+	"rust":       {".rs", ".toml"},
+	"elixir":     {".ex", ".exs"},
+}
+
+// template is written by Init. Every setting is present and commented out,
+// so the file documents what can be configured.
+const template = `# OmniPath settings. Flags given on the command line override these.
+
+# Command used to open URLs instead of the platform's default browser,
+# e.g. "firefox" or "open -a Safari".
+# browser: firefox
+
+# Port "omnipath readme" serves on.
+# readme_port: 8080
+
+# Whether "omnipath readme" opens the rendered README in the browser.
+# auto_open: true
+
+# Directories that are never scanned for workspace members or dependencies,
+# matched against their path and their name.
+# scan_ignore:
+#   - node_modules
+#   - vendor
+
+# Print URLs instead of opening them in the browser.
+# print_mode: false
+
+# File extensions "omnipath run --watch" restarts a service for, by the
+# language of its command: go, python, ruby, php, javascript, rust or elixir.
+# Other commands restart for any of these.
+# watch_extensions:
+#   go: [.go, .mod]
+#   python: [.py]
+
+# Keys of the interactive screens, by action. Each action takes a key or a
+# list of keys, replacing its defaults; actions not listed keep theirs. Press
+# ? in the service list, or ctrl+g ? in the multiplexer, to see its keys.
+# keys:
+#   # Actions: up, down, toggle, select_all, select_none, invert, filter,
+#   # confirm, help, quit.
+#   select:
+#     toggle: [space, x]
+#   # Actions: prefix, prev_session, next_session, single_view, split_view,
+#   # page_up, page_down, search, next_match, prev_match, end_search, clear,
+#   # copy, restart, help, quit. Keys without ctrl+ or alt+, other than the
+#   # page keys, follow the prefix key so they can still be typed into
+#   # services.
+#   multiplexer:
+#     prev_session: [ctrl+left, ctrl+p]
+#     next_session: [ctrl+right, ctrl+n]
+`
+
+// Path returns where the config file is read from, config.yaml in an
+// omnipath directory under the user's config directory, such as
+// ~/.config/omnipath/config.yaml on Linux.
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locating config directory: %w", err)
+	}
+	return filepath.Join(dir, "omnipath", "config.yaml"), nil
+}
+
+// Load sets the defaults of every setting and reads the config file into
+// viper's global registry. A missing config file isn't an error.
+func Load() error {
+	viper.SetDefault(KeyReadmePort, "8080")
+	viper.SetDefault(KeyAutoOpen, true)
+	viper.SetDefault(KeyScanIgnore, []string{"node_modules", "vendor", ".git"})
+	for language, extensions := range DefaultWatchExtensions {
+		viper.SetDefault(KeyWatchExtensions+"."+language, extensions)
+	}
+
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	viper.SetConfigFile(path)
+	if err := viper.ReadInConfig(); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	return nil
+}
+
+// Init writes a commented config file to path, creating its directory. It
+// returns an error wrapping fs.ErrExist if the file already exists.
+func Init(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(template); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,92 @@
+package detect
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// --- Deno Detector Implementation ---
+
+type denoDetector struct{}
+
+func (d denoDetector) Name() string {
+	return "Deno"
+}
+
+func (d denoDetector) Detect(dir string) bool {
+	return denoConfig(dir) != ""
+}
+
+func (d denoDetector) GetServices(dir string) []Service {
+	var services []Service
+	for _, task := range denoTasks(filepath.Join(dir, denoConfig(dir))) {
+		services = append(services, Service{
+			Name:        "Deno Task " + task,
+			Command:     "deno task " + task,
+			Interactive: true,
+			Priority:    denoTaskPriority(task),
+		})
+	}
+	if len(services) > 0 {
+		return services
+	}
+
+	// Without tasks, run the conventional entrypoint.
+	for _, entry := range []string{"main.ts", "main.tsx", "main.js", "mod.ts"} {
+		if fileExists(filepath.Join(dir, entry)) {
+			return []Service{{
+				Name:        "Deno Run",
+				Command:     "deno run " + entry,
+				Interactive: true,
+				Priority:    PriorityApp,
+			}}
+		}
+	}
+	return nil
+}
+
+// denoConfig returns the name of the Deno config file in dir, or "" if there
+// is none.
+func denoConfig(dir string) string {
+	for _, name := range []string{"deno.json", "deno.jsonc"} {
+		if fileExists(filepath.Join(dir, name)) {
+			return name
+		}
+	}
+	return ""
+}
+
+// denoTasks returns the sorted names of the tasks in a deno.json or
+// deno.jsonc file.
+func denoTasks(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var config struct {
+		Tasks map[string]json.RawMessage `json:"tasks"`
+	}
+	if err := json.Unmarshal(stripJSONC(data), &config); err != nil {
+		return nil
+	}
+	var tasks []string
+	for name := range config.Tasks {
+		tasks = append(tasks, name)
+	}
+	sort.Strings(tasks)
+	return tasks
+}
+
+// denoTaskPriority returns the priority of a Deno task service: the tasks
+// named like the runnable package.json scripts start the app, and any others
+// are helpers.
+func denoTaskPriority(task string) int {
+	for _, script := range runnableJSScripts {
+		if task == script {
+			return jsScriptPriority(script)
+		}
+	}
+	return PriorityAuxiliary
+}
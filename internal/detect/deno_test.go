@@ -0,0 +1,32 @@
+package detect
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDenoStartTask(t *testing.T) {
+	configs := map[string]string{
+		"deno.json": `{
+  "tasks": {
+    "start": "deno run --allow-net main.ts"
+  }
+}`,
+		"deno.jsonc": `{
+  // Run the server
+  "tasks": {
+    "start": "deno run --allow-net main.ts", /* the app */
+  },
+}`,
+	}
+	for name, config := range configs {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{name: config, "main.ts": "Deno.serve(() => new Response());\n"})
+		if !(denoDetector{}).Detect(dir) {
+			t.Fatalf("%s: no Deno project detected", name)
+		}
+		if got, want := commands(denoDetector{}.GetServices(dir)), []string{"deno task start"}; !slices.Equal(got, want) {
+			t.Errorf("%s: commands = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package detect
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// composeFiles lists the file names Docker Compose looks for, in its order of preference.
+var composeFiles = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+// --- Docker Detector Implementation ---
+
+type dockerDetector struct{}
+
+func (d dockerDetector) Name() string {
+	return "Docker"
+}
+
+func (d dockerDetector) Detect(dir string) bool {
+	return composeFile(dir) != "" || fileExists(filepath.Join(dir, "Dockerfile"))
+}
+
+func (d dockerDetector) GetServices(dir string) []Service {
+	if file := composeFile(dir); file != "" {
+		services := []Service{{
+			Name:        "Docker Compose Up",
+			Command:     "docker compose up",
+			Interactive: true,
+			Priority:    PriorityStack,
+		}}
+		// Offer each compose service individually when there's a choice.
+		if names := composeServiceNames(file); len(names) > 1 {
+			for _, name := range names {
+				services = append(services, Service{
+					Name:        fmt.Sprintf("Docker Compose Up (%s)", name),
+					Command:     "docker compose up " + name,
+					Interactive: true,
+					Priority:    PriorityAuxiliary,
+				})
+			}
+		}
+		return services
+	}
+
+	image := dockerImageName(dir)
+	return []Service{{
+		Name:        "Docker Build & Run",
+		Command:     fmt.Sprintf("docker build -t %s . && docker run --rm -i %s", image, image),
+		Interactive: true,
+		Priority:    PriorityApp,
+	}}
+}
+
+// composeFile returns the path of the compose file in dir, or an empty string
+// if there is none.
+func composeFile(dir string) string {
+	for _, name := range composeFiles {
+		if path := filepath.Join(dir, name); fileExists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// composeServiceNames returns the keys of the top-level "services" mapping in
+// a compose file, in the order they are declared.
+func composeServiceNames(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var doc struct {
+		Services yaml.Node `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil || doc.Services.Kind != yaml.MappingNode {
+		return nil
+	}
+	var names []string
+	for i := 0; i < len(doc.Services.Content); i += 2 {
+		names = append(names, doc.Services.Content[i].Value)
+	}
+	return names
+}
+
+var invalidImageChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// dockerImageName derives a local image tag from the project directory name.
+func dockerImageName(dir string) string {
+	name := "omnipath-app"
+	if wd, err := filepath.Abs(dir); err == nil {
+		if base := invalidImageChars.ReplaceAllString(strings.ToLower(filepath.Base(wd)), "-"); strings.Trim(base, "-._") != "" {
+			name = strings.Trim(base, "-._")
+		}
+	}
+	return name
+}
@@ -0,0 +1,76 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// --- Elixir Detector Implementation ---
+
+type elixirDetector struct{}
+
+func (d elixirDetector) Name() string {
+	return "Elixir"
+}
+
+func (d elixirDetector) Detect(dir string) bool {
+	return fileExists(filepath.Join(dir, "mix.exs"))
+}
+
+func (d elixirDetector) GetServices(dir string) []Service {
+	deps := mixDeps(filepath.Join(dir, "mix.exs"))
+	if deps["phoenix"] {
+		return []Service{
+			{
+				Name:        "Phoenix Server",
+				Command:     "mix phx.server",
+				Interactive: true,
+				Priority:    PriorityApp,
+			},
+			{
+				Name:        "Phoenix Server (IEx)",
+				Command:     "iex -S mix phx.server",
+				Interactive: true,
+				Priority:    PriorityAuxiliary,
+			},
+		}
+	}
+	return []Service{
+		{
+			Name:        "Mix Run",
+			Command:     "mix run --no-halt",
+			Interactive: true,
+			Priority:    PriorityApp,
+		},
+		{
+			Name:        "IEx",
+			Command:     "iex -S mix",
+			Interactive: true,
+			Priority:    PriorityAuxiliary,
+		},
+	}
+}
+
+// mixDepPattern matches the package atom at the start of a dependency tuple,
+// e.g. {:phoenix, "~> 1.7"}.
+var mixDepPattern = regexp.MustCompile(`\{\s*:(\w+)\s*,`)
+
+// mixDeps returns the packages listed in the deps function of a mix.exs,
+// scanning from "defp deps" to the end of the file rather than parsing Elixir.
+func mixDeps(path string) map[string]bool {
+	deps := make(map[string]bool)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return deps
+	}
+	source := string(content)
+	if i := strings.Index(source, "defp deps"); i >= 0 {
+		source = source[i:]
+	}
+	for _, match := range mixDepPattern.FindAllStringSubmatch(source, -1) {
+		deps[match[1]] = true
+	}
+	return deps
+}
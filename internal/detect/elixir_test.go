@@ -0,0 +1,59 @@
+package detect
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestElixirServices(t *testing.T) {
+	tests := []struct {
+		name, mixExs string
+		want         []string
+	}{
+		{
+			name: "Phoenix",
+			mixExs: `defmodule Shop.MixProject do
+  use Mix.Project
+
+  def project do
+    [app: :shop, deps: deps()]
+  end
+
+  defp deps do
+    [
+      {:phoenix, "~> 1.7"},
+      {:ecto_sql, "~> 3.10"}
+    ]
+  end
+end
+`,
+			want: []string{"mix phx.server", "iex -S mix phx.server"},
+		},
+		{
+			name: "plain mix project",
+			mixExs: `defmodule Worker.MixProject do
+  use Mix.Project
+
+  def project do
+    [app: :worker, deps: deps()]
+  end
+
+  defp deps do
+    [{:jason, "~> 1.4"}]
+  end
+end
+`,
+			want: []string{"mix run --no-halt", "iex -S mix"},
+		},
+	}
+	for _, tt := range tests {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"mix.exs": tt.mixExs})
+		if !(elixirDetector{}).Detect(dir) {
+			t.Fatalf("%s: no Elixir project detected", tt.name)
+		}
+		if got := commands(elixirDetector{}.GetServices(dir)); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: commands = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package detect
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigFile is the project-level file users can add to define their own run services.
+const ProjectConfigFile = "omnipath.yaml"
+
+// projectConfig mirrors the structure of omnipath.yaml.
+type projectConfig struct {
+	// Override discards auto-detected services entirely when true. Otherwise
+	// configured services are listed first and replace any auto-detected
+	// service with the same name.
+	Override bool `yaml:"override"`
+	Services []struct {
+		Name        string `yaml:"name"`
+		Command     string `yaml:"command"`
+		Interactive bool   `yaml:"interactive"`
+		Dir         string `yaml:"dir"`
+	} `yaml:"services"`
+}
+
+// loadProjectConfig reads omnipath.yaml from dir. It returns nil without an
+// error when the file doesn't exist.
+func loadProjectConfig(dir string) (*projectConfig, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ProjectConfigFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cfg projectConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", ProjectConfigFile, err)
+	}
+	return &cfg, nil
+}
+
+// --- omnipath.yaml Detector Implementation ---
+
+type fileDetector struct{}
+
+func (d fileDetector) Name() string {
+	return ProjectConfigFile
+}
+
+func (d fileDetector) Detect(dir string) bool {
+	return fileExists(filepath.Join(dir, ProjectConfigFile))
+}
+
+func (d fileDetector) GetServices(dir string) []Service {
+	cfg, err := loadProjectConfig(dir)
+	if err != nil {
+		log.Printf("Ignoring %s: %v", ProjectConfigFile, err)
+		return nil
+	}
+	if cfg == nil {
+		return nil
+	}
+	var services []Service
+	for i, s := range cfg.Services {
+		if s.Name == "" || s.Command == "" {
+			log.Printf("Skipping service #%d in %s: name and command are required", i+1, ProjectConfigFile)
+			continue
+		}
+		services = append(services, Service{
+			Name:        s.Name,
+			Command:     s.Command,
+			Interactive: s.Interactive,
+			Priority:    PriorityConfigured,
+			Dir:         s.Dir,
+		})
+	}
+	return services
+}
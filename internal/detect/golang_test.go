@@ -0,0 +1,50 @@
+package detect
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGoCmdBinaries(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod":              "module example.com/app\n",
+		"cmd/server/main.go":  "package main\n",
+		"cmd/worker/main.go":  "package main\n",
+		"cmd/README.md":       "Commands\n",
+		"internal/db/conn.go": "package db\n",
+	})
+
+	services := goDetector{}.GetServices(dir)
+	if want := []string{"go run ./cmd/server", "go run ./cmd/worker"}; !slices.Equal(commands(services), want) {
+		t.Fatalf("commands = %q, want %q", commands(services), want)
+	}
+	if services[0].Name != "Go Server" || services[1].Name != "Go Worker" {
+		t.Errorf("names = %q and %q, want Go Server and Go Worker", services[0].Name, services[1].Name)
+	}
+	if !services[1].Interactive {
+		t.Error("cmd/worker isn't interactive, though workers run until stopped")
+	}
+}
+
+func TestGoRootMainPackage(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod":              "module example.com/app\n",
+		"main.go":             "package main\n",
+		"cmd/server/main.go":  "package main\n",
+		"cmd/migrate/main.go": "package main\n",
+	})
+
+	// cmd/server comes before the root package, other binaries after it.
+	want := []string{"go run ./cmd/server", "go run .", "go run ./cmd/migrate"}
+	if got := commands(goDetector{}.GetServices(dir)); !slices.Equal(got, want) {
+		t.Errorf("commands = %q, want %q", got, want)
+	}
+
+	only := t.TempDir()
+	writeFiles(t, only, map[string]string{"go.mod": "module example.com/app\n", "main.go": "package main\n"})
+	if got := (goDetector{}).GetServices(only); len(got) != 1 || got[0].Command != "go run ." || got[0].Name != "Go App" {
+		t.Errorf("services = %+v, want Go App running go run .", got)
+	}
+}
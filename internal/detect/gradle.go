@@ -0,0 +1,125 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// --- Gradle Detector Implementation ---
+
+type gradleDetector struct{}
+
+// gradleBuildFiles are the build scripts whose plugins decide which task to
+// offer: the root project's and, for Android, the conventional app module's.
+var gradleBuildFiles = []string{
+	"build.gradle", "build.gradle.kts",
+	"app/build.gradle", "app/build.gradle.kts",
+}
+
+var (
+	// gradlePluginID matches id 'x', id "x" and id("x") declarations.
+	gradlePluginID = regexp.MustCompile(`^id\s*\(?\s*["']([\w.-]+)["']`)
+	// gradleKotlinPlugin matches the kotlin("jvm") shorthand.
+	gradleKotlinPlugin = regexp.MustCompile(`^kotlin\s*\(\s*["']([\w.-]+)["']`)
+	// gradleCorePlugin matches core plugins listed by bare name, such as
+	// application or `java-library`.
+	gradleCorePlugin = regexp.MustCompile("^`?([a-z][\\w-]*)`?$")
+	// gradleApplyPlugin matches the legacy apply plugin: 'x' form.
+	gradleApplyPlugin = regexp.MustCompile(`apply\s+plugin\s*:\s*["']([\w.-]+)["']`)
+)
+
+func (d gradleDetector) Name() string {
+	return "Gradle"
+}
+
+func (d gradleDetector) Detect(dir string) bool {
+	for _, name := range []string{"gradlew", "build.gradle", "build.gradle.kts", "settings.gradle", "settings.gradle.kts"} {
+		if fileExists(filepath.Join(dir, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d gradleDetector) GetServices(dir string) []Service {
+	// Prefer the wrapper, which pins the Gradle version the project expects.
+	gradle := "gradle"
+	if fileExists(filepath.Join(dir, "gradlew")) {
+		gradle = "./gradlew"
+	}
+
+	plugins := make(map[string]bool)
+	for _, name := range gradleBuildFiles {
+		for _, id := range gradlePlugins(filepath.Join(dir, name)) {
+			plugins[id] = true
+		}
+	}
+
+	service := func(name, task string, priority int) Service {
+		return Service{
+			Name:        name,
+			Command:     gradle + " " + task,
+			Interactive: true,
+			Priority:    priority,
+		}
+	}
+	var services []Service
+	if plugins["org.springframework.boot"] {
+		services = append(services, service("Gradle Boot Run", "bootRun", PriorityApp))
+	}
+	if plugins["application"] {
+		services = append(services, service("Gradle Run", "run", PriorityApp))
+	}
+	if plugins["com.android.application"] {
+		services = append(services, service("Gradle Install Debug", "installDebug", PriorityApp))
+	}
+	if len(services) > 0 {
+		return services
+	}
+	if plugins["distribution"] {
+		return []Service{service("Gradle Install Dist", "installDist", PriorityApp)}
+	}
+	return []Service{service("Gradle Build", "build", PriorityAuxiliary)}
+}
+
+// gradlePlugins returns the IDs of the plugins a Groovy or Kotlin build
+// script applies, from its plugins { } block and any apply plugin: lines.
+// The script is scanned loosely rather than evaluated.
+func gradlePlugins(path string) []string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	source := string(content)
+
+	var ids []string
+	for _, m := range gradleApplyPlugin.FindAllStringSubmatch(source, -1) {
+		ids = append(ids, m[1])
+	}
+
+	start := strings.Index(source, "plugins {")
+	if start < 0 {
+		return ids
+	}
+	block := source[start+len("plugins {"):]
+	if end := strings.Index(block, "}"); end >= 0 {
+		block = block[:end]
+	}
+	for _, line := range strings.Split(block, "\n") {
+		line = strings.TrimSpace(line)
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		switch {
+		case gradlePluginID.MatchString(line):
+			ids = append(ids, gradlePluginID.FindStringSubmatch(line)[1])
+		case gradleKotlinPlugin.MatchString(line):
+			ids = append(ids, "org.jetbrains.kotlin."+gradleKotlinPlugin.FindStringSubmatch(line)[1])
+		case gradleCorePlugin.MatchString(line):
+			ids = append(ids, gradleCorePlugin.FindStringSubmatch(line)[1])
+		}
+	}
+	return ids
+}
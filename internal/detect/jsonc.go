@@ -0,0 +1,64 @@
+package detect
+
+import "bytes"
+
+// stripJSONC turns JSON with comments, as used by deno.jsonc and VS Code's
+// settings, into plain JSON by removing comments and then trailing commas.
+func stripJSONC(data []byte) []byte {
+	var noComments []byte
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			if c == '\\' && i+1 < len(data) {
+				noComments = append(noComments, c)
+				i++
+				c = data[i]
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i+1 < len(data) && data[i+1] != '\n' {
+				i++
+			}
+			continue
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				i = len(data)
+			} else {
+				i += end + 3
+			}
+			continue
+		}
+		noComments = append(noComments, c)
+	}
+
+	var out []byte
+	inString = false
+	for i := 0; i < len(noComments); i++ {
+		c := noComments[i]
+		switch {
+		case inString:
+			if c == '\\' && i+1 < len(noComments) {
+				out = append(out, c)
+				i++
+				c = noComments[i]
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == ',':
+			rest := bytes.TrimLeft(noComments[i+1:], " \t\r\n")
+			if len(rest) > 0 && (rest[0] == '}' || rest[0] == ']') {
+				continue
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
@@ -0,0 +1,42 @@
+package detect
+
+import (
+	"path/filepath"
+)
+
+// --- Jupyter Detector Implementation ---
+
+type jupyterDetector struct{}
+
+func (d jupyterDetector) Name() string {
+	return "Jupyter"
+}
+
+// Detect looks for notebooks in dir or its immediate subdirectories, such as
+// notebooks/, or for Jupyter in the Python requirements.
+func (d jupyterDetector) Detect(dir string) bool {
+	for _, pattern := range []string{"*.ipynb", filepath.Join("*", "*.ipynb")} {
+		if notebooks, _ := filepath.Glob(filepath.Join(dir, pattern)); len(notebooks) > 0 {
+			return true
+		}
+	}
+	return pythonMentions(dir, "jupyter") || pythonMentions(dir, "notebook")
+}
+
+func (d jupyterDetector) GetServices(dir string) []Service {
+	// JupyterLab is the newer interface; prefer it when it's available.
+	if pythonMentions(dir, "jupyterlab") || hasCommand("jupyter-lab") {
+		return []Service{{
+			Name:        "Jupyter Lab",
+			Command:     "jupyter lab",
+			Interactive: true,
+			Priority:    PriorityApp,
+		}}
+	}
+	return []Service{{
+		Name:        "Jupyter Notebook",
+		Command:     "jupyter notebook",
+		Interactive: true,
+		Priority:    PriorityApp,
+	}}
+}
@@ -0,0 +1,83 @@
+package detect
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// makefiles lists the file names make looks for, in its order of preference.
+var makefiles = []string{"GNUmakefile", "makefile", "Makefile"}
+
+// runnableMakeTargets are the targets offered as services, in display order.
+var runnableMakeTargets = []string{"run", "dev", "serve", "start"}
+
+// makeTargetPattern matches a rule definition such as "dev:", "run: build" or
+// the double-colon "dev::", but not variable assignments like "X := y" or
+// "X ::= y". Special targets such as .PHONY and pattern rules like "%.o:" are
+// excluded by the character class.
+var makeTargetPattern = regexp.MustCompile(`^([a-zA-Z0-9_-]+)\s*::?([^=:]|$)`)
+
+// --- Makefile Detector Implementation ---
+
+type makeDetector struct{}
+
+func (d makeDetector) Name() string {
+	return "Make"
+}
+
+func (d makeDetector) Detect(dir string) bool {
+	return findMakefile(dir) != ""
+}
+
+func (d makeDetector) GetServices(dir string) []Service {
+	targets := make(map[string]bool)
+	for _, t := range makeTargets(findMakefile(dir)) {
+		targets[t] = true
+	}
+	var services []Service
+	for _, t := range runnableMakeTargets {
+		if targets[t] {
+			services = append(services, Service{
+				Name:        "Make " + t,
+				Command:     "make " + t,
+				Interactive: true,
+				Priority:    PriorityApp,
+			})
+		}
+	}
+	return services
+}
+
+// findMakefile returns the path of the Makefile in dir, or an empty string if
+// there is none.
+func findMakefile(dir string) string {
+	for _, name := range makefiles {
+		if path := filepath.Join(dir, name); fileExists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// makeTargets returns the names of the explicit targets defined in a Makefile,
+// in the order they appear.
+func makeTargets(path string) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	seen := make(map[string]bool)
+	var targets []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if m := makeTargetPattern.FindStringSubmatch(scanner.Text()); m != nil && !seen[m[1]] {
+			seen[m[1]] = true
+			targets = append(targets, m[1])
+		}
+	}
+	return targets
+}
@@ -0,0 +1,43 @@
+package detect
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMakeTargets(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"Makefile": `.PHONY: run dev serve start build
+PORT := 8080
+CFLAGS = -O2
+DEBUG ?= 0
+VERSION::=1.0
+
+%.o: %.c
+	$(CC) -c $<
+
+build:
+	go build ./...
+
+start: build
+	./app
+
+serve:
+	python -m http.server $(PORT)
+
+dev:: build
+	air
+
+run: ; ./app
+
+# test: is only mentioned in a comment
+`})
+
+	if got, want := makeTargets(findMakefile(dir)), []string{"build", "start", "serve", "dev", "run"}; !slices.Equal(got, want) {
+		t.Errorf("makeTargets = %q, want %q", got, want)
+	}
+	want := []string{"make run", "make dev", "make serve", "make start"}
+	if got := commands(makeDetector{}.GetServices(dir)); !slices.Equal(got, want) {
+		t.Errorf("commands = %q, want %q", got, want)
+	}
+}
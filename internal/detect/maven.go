@@ -0,0 +1,69 @@
+package detect
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+)
+
+// --- Maven Detector Implementation ---
+
+type mavenDetector struct{}
+
+func (d mavenDetector) Name() string {
+	return "Maven"
+}
+
+func (d mavenDetector) Detect(dir string) bool {
+	return fileExists(filepath.Join(dir, "pom.xml"))
+}
+
+func (d mavenDetector) GetServices(dir string) []Service {
+	// Prefer the wrapper, which pins the Maven version the project expects.
+	mvn := "mvn"
+	if fileExists(filepath.Join(dir, "mvnw")) {
+		mvn = "./mvnw"
+	}
+
+	for _, plugin := range mavenPlugins(filepath.Join(dir, "pom.xml")) {
+		if plugin == "spring-boot-maven-plugin" {
+			return []Service{{
+				Name:        "Maven Spring Boot Run",
+				Command:     mvn + " spring-boot:run",
+				Interactive: true,
+				Priority:    PriorityApp,
+			}}
+		}
+	}
+	return []Service{{
+		Name:        "Maven Package",
+		Command:     mvn + " package",
+		Interactive: true,
+		Priority:    PriorityAuxiliary,
+	}}
+}
+
+// mavenPlugins returns the artifact IDs of the plugins a pom.xml declares in
+// its build. Plugins only listed under pluginManagement aren't applied, so
+// they are left out.
+func mavenPlugins(path string) []string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var pom struct {
+		Build struct {
+			Plugins []struct {
+				ArtifactID string `xml:"artifactId"`
+			} `xml:"plugins>plugin"`
+		} `xml:"build"`
+	}
+	if err := xml.Unmarshal(content, &pom); err != nil {
+		return nil
+	}
+	var plugins []string
+	for _, p := range pom.Build.Plugins {
+		plugins = append(plugins, p.ArtifactID)
+	}
+	return plugins
+}
@@ -0,0 +1,37 @@
+package detect
+
+import (
+	"slices"
+	"testing"
+)
+
+const springBootPom = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <modelVersion>4.0.0</modelVersion>
+  <artifactId>demo</artifactId>
+  <build>
+    <plugins>
+      <plugin>
+        <groupId>org.springframework.boot</groupId>
+        <artifactId>spring-boot-maven-plugin</artifactId>
+      </plugin>
+    </plugins>
+  </build>
+</project>
+`
+
+func TestMavenSpringBoot(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"pom.xml": springBootPom})
+	if !(mavenDetector{}).Detect(dir) {
+		t.Fatal("no Maven project detected")
+	}
+	if got, want := commands((mavenDetector{}).GetServices(dir)), []string{"mvn spring-boot:run"}; !slices.Equal(got, want) {
+		t.Errorf("commands without mvnw = %q, want %q", got, want)
+	}
+
+	writeFiles(t, dir, map[string]string{"mvnw": "#!/bin/sh\n"})
+	if got, want := commands((mavenDetector{}).GetServices(dir)), []string{"./mvnw spring-boot:run"}; !slices.Equal(got, want) {
+		t.Errorf("commands with mvnw = %q, want %q", got, want)
+	}
+}
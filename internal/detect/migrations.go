@@ -0,0 +1,68 @@
+package detect
+
+// migrationDetector is implemented by detectors that can offer a service
+// applying the project's database migrations. Like test services, migration
+// services are only offered on request.
+type migrationDetector interface {
+	Detector
+	MigrationServices(dir string) []Service
+}
+
+// migrationDetectors returns every detector that offers migration services.
+func migrationDetectors() []Detector {
+	return []Detector{
+		goDetector{},
+		phpDetector{},
+		jsDetector{},
+		pythonDetector{},
+		rubyDetector{},
+	}
+}
+
+// GetMigrationServices returns the services that apply the database
+// migrations of the project in the current directory, such as
+// "php artisan migrate" or "bin/rails db:migrate".
+func GetMigrationServices() []Service {
+	return migrationServicesIn(".")
+}
+
+// GetWorkspaceMigrationServices is like GetWorkspaceServices, but returns
+// migration services as GetMigrationServices does.
+func GetWorkspaceMigrationServices() []Service {
+	return withWorkspaces(migrationServicesIn)
+}
+
+// migrationServicesIn returns the migration services of the project in dir,
+// ordered by priority like servicesIn.
+func migrationServicesIn(dir string) []Service {
+	return requestedServicesIn(dir, migrationDetectors(), migrationServicesOf)
+}
+
+// GetMigrationServicesFrom is like GetMigrationServices, but only includes
+// the migration services of d, if it offers any.
+func GetMigrationServicesFrom(d Detector) []Service {
+	return migrationServicesFrom(d, ".")
+}
+
+// GetWorkspaceMigrationServicesFrom is like GetWorkspaceMigrationServices,
+// but only includes the migration services of d, as GetMigrationServicesFrom
+// does.
+func GetWorkspaceMigrationServicesFrom(d Detector) []Service {
+	return withWorkspaces(func(dir string) []Service {
+		return migrationServicesFrom(d, dir)
+	})
+}
+
+// migrationServicesFrom returns the migration services d finds in dir,
+// ordered by priority.
+func migrationServicesFrom(d Detector, dir string) []Service {
+	return requestedServicesIn(dir, []Detector{d}, migrationServicesOf)
+}
+
+// migrationServicesOf returns the migration services d offers in dir, if any.
+func migrationServicesOf(d Detector, dir string) []Service {
+	if md, ok := d.(migrationDetector); ok {
+		return md.MigrationServices(dir)
+	}
+	return nil
+}
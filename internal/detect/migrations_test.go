@@ -0,0 +1,31 @@
+package detect
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLaravelMigrationIsOnlyOfferedOnRequest(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"artisan":          "#!/usr/bin/env php\n",
+		"composer.json":    `{"require": {"laravel/framework": "^11.0"}}`,
+		"public/index.php": "<?php\n",
+	})
+
+	want := []string{"php artisan migrate"}
+	if got := commands(migrationServicesIn(dir)); !slices.Equal(got, want) {
+		t.Errorf("migration services = %q, want %q", got, want)
+	}
+	if got := commands(migrationServicesFrom(phpDetector{}, dir)); !slices.Equal(got, want) {
+		t.Errorf("PHP migration services = %q, want %q", got, want)
+	}
+	for _, s := range servicesIn(dir) {
+		if s.Command == "php artisan migrate" {
+			t.Errorf("GetServices offers %s", s.Name)
+		}
+	}
+	if got := commands(migrationServicesFrom(rustDetector{}, dir)); len(got) != 0 {
+		t.Errorf("Rust migration services = %q, want none", got)
+	}
+}
@@ -0,0 +1,90 @@
+package detect
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// --- Procfile Detector Implementation ---
+
+type procfileDetector struct{}
+
+// procfiles are the foreman-style process files, most specific first. Only
+// the first one found is used.
+var procfiles = []string{"Procfile.dev", "Procfile"}
+
+// procfileLinePattern matches a "name: command" process declaration.
+var procfileLinePattern = regexp.MustCompile(`^([A-Za-z0-9_-]+):\s*(.+)$`)
+
+func (d procfileDetector) Name() string {
+	return "Procfile"
+}
+
+func (d procfileDetector) Detect(dir string) bool {
+	return findProcfile(dir) != ""
+}
+
+func (d procfileDetector) GetServices(dir string) []Service {
+	name := findProcfile(dir)
+	path := filepath.Join(dir, name)
+	processes, err := parseProcfile(path)
+	if err != nil {
+		log.Printf("Ignoring %s: %v", path, err)
+		return nil
+	}
+	var services []Service
+	for _, p := range processes {
+		services = append(services, Service{
+			Name:        fmt.Sprintf("%s (%s)", p.name, name),
+			Command:     p.command,
+			Interactive: true,
+			Priority:    PriorityProcfile,
+		})
+	}
+	return services
+}
+
+// findProcfile returns the name of the Procfile in dir, or an empty string if
+// there is none.
+func findProcfile(dir string) string {
+	for _, name := range procfiles {
+		if fileExists(filepath.Join(dir, name)) {
+			return name
+		}
+	}
+	return ""
+}
+
+// procfileProcess is a single named process from a Procfile.
+type procfileProcess struct {
+	name    string
+	command string
+}
+
+// parseProcfile reads the processes declared in a Procfile, in file order,
+// skipping blank lines, comments and anything that isn't "name: command".
+func parseProcfile(path string) ([]procfileProcess, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var processes []procfileProcess
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if m := procfileLinePattern.FindStringSubmatch(line); m != nil {
+			processes = append(processes, procfileProcess{name: m[1], command: strings.TrimSpace(m[2])})
+		}
+	}
+	return processes, scanner.Err()
+}
@@ -0,0 +1,30 @@
+package detect
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestProcfileServices(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"Procfile": `# Processes started by foreman
+web: bundle exec puma -C config/puma.rb
+
+   # Assets
+css: bin/rails tailwindcss:watch
+worker:bundle exec sidekiq
+`})
+
+	services := procfileDetector{}.GetServices(dir)
+	var names []string
+	for _, s := range services {
+		names = append(names, s.Name)
+	}
+	if want := []string{"web (Procfile)", "css (Procfile)", "worker (Procfile)"}; !slices.Equal(names, want) {
+		t.Fatalf("services = %q, want %q", names, want)
+	}
+	want := []string{"bundle exec puma -C config/puma.rb", "bin/rails tailwindcss:watch", "bundle exec sidekiq"}
+	if got := commands(services); !slices.Equal(got, want) {
+		t.Errorf("commands = %q, want %q", got, want)
+	}
+}
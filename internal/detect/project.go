@@ -7,21 +7,40 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // Service represents a runnable service with a name, command, and an interactive flag.
 type Service struct {
-	Name        string
-	Command     string
-	Interactive bool
+	Name        string `json:"name"`
+	Command     string `json:"command"`
+	Interactive bool   `json:"interactive"`
+	Priority    int    `json:"priority"`      // How strong a default the service is; see the Priority constants.
+	Dir         string `json:"dir,omitempty"` // Working directory to run the command in, relative to the project; empty means the project root.
 }
 
+// Service priorities, highest first. GetServices lists services in priority
+// order, so the first one is the best service to run by default. Services
+// without a priority are listed last.
+const (
+	PriorityConfigured = 100 // Defined by the user in omnipath.yaml.
+	PriorityProcfile   = 90  // Declared in a Procfile, as the project intends it to be run.
+	PriorityStack      = 70  // Starts the whole dev stack, e.g. bin/dev, Sail or docker compose up.
+	PriorityApp        = 50  // Runs the project's main app or dev server.
+	PriorityAuxiliary  = 20  // Alternatives and helpers such as watchers, REPLs and Storybook.
+)
+
 // Detector defines the interface for project entrypoint detection.
-// Now each detector returns a slice of Service values.
+// Now each detector returns a slice of Service values. Both methods look at
+// the project in dir; the returned commands are meant to be run from there.
 type Detector interface {
 	Name() string
-	Detect() bool
-	GetServices() []Service
+	Detect(dir string) bool
+	GetServices(dir string) []Service
 }
 
 // --- Go Detector Implementation ---
@@ -32,63 +51,102 @@ func (d goDetector) Name() string {
 	return "Go"
 }
 
-func (d goDetector) Detect() bool {
-	_, err := os.Stat("go.mod")
-	return err == nil
+func (d goDetector) Detect(dir string) bool {
+	return fileExists(filepath.Join(dir, "go.mod"))
 }
 
-// getGoServices returns Go service options based on which files exist.
-func getGoServices() []Service {
+// goDaemonNames are names of cmd/ binaries that are usually long-running,
+// besides those looksLongRunning recognises.
+var goDaemonNames = []string{"worker", "api", "web", "daemon", "gateway", "proxy"}
+
+// getGoServices returns a service for each Go program in dir: Air when
+// .air.toml exists, the root main package, and every cmd/<name>/main.go.
+// cmd/server comes before the root package, as the likelier default.
+func getGoServices(dir string) []Service {
 	var services []Service
-	// If .air.toml exists, only present "Air" (interactive).
-	if _, err := os.Stat(".air.toml"); err == nil {
+	if fileExists(filepath.Join(dir, ".air.toml")) {
 		services = append(services, Service{
 			Name:        "Air",
 			Command:     "air",
 			Interactive: true,
+			Priority:    PriorityApp,
 		})
-		return services
-	}
-	// If ./cmd/server/main.go exists, offer that as interactive.
-	if _, err := os.Stat("./cmd/server/main.go"); err == nil {
-		services = append(services, Service{
-			Name:        "Go Server",
-			Command:     "go run ./cmd/server/main.go",
-			Interactive: true,
-		})
-		return services
 	}
-	//if ./main.go exists, it's just a command.
-	if _, err := os.Stat("./main.go"); err == nil {
-		services = append(services, Service{
-			Name:        "Go App",
-			Command:     "go run ./main.go",
-			Interactive: false,
-		})
-		return services
+
+	hasRoot := fileExists(filepath.Join(dir, "main.go"))
+	mains, _ := filepath.Glob(filepath.Join(dir, "cmd", "*", "main.go"))
+	var binaries []Service
+	for _, main := range mains {
+		name := filepath.Base(filepath.Dir(main))
+		label := "Go " + titleCase(name)
+		// cmd/main is the project's app, unless it has a root main package.
+		if name == "main" && !hasRoot {
+			label = "Go App"
+		}
+		service := Service{
+			Name:        label,
+			Command:     "go run ./cmd/" + name,
+			Interactive: looksLongRunning(name) || slices.Contains(goDaemonNames, name),
+			Priority:    PriorityApp,
+		}
+		if name == "server" {
+			services = append(services, service)
+		} else {
+			binaries = append(binaries, service)
+		}
 	}
-	// Otherwise, if it's just a command (cmd/main/main.go or cmd/main.go), run it non-interactively.
-	if _, err := os.Stat("./cmd/main/main.go"); err == nil {
+	if hasRoot {
 		services = append(services, Service{
-			Name:        "Go App",
-			Command:     "go run ./cmd/main/main.go",
-			Interactive: false,
+			Name:     "Go App",
+			Command:  "go run .",
+			Priority: PriorityApp,
 		})
-		return services
 	}
-	if _, err := os.Stat("./cmd/main.go"); err == nil {
+	services = append(services, binaries...)
+
+	// A lone cmd/main.go is a command of its own.
+	if len(mains) == 0 && !hasRoot && fileExists(filepath.Join(dir, "cmd/main.go")) {
 		services = append(services, Service{
-			Name:        "Go App",
-			Command:     "go run ./cmd/main.go",
-			Interactive: false,
+			Name:     "Go App",
+			Command:  "go run ./cmd/main.go",
+			Priority: PriorityApp,
 		})
-		return services
 	}
 	return services
 }
 
-func (d goDetector) GetServices() []Service {
-	return getGoServices()
+func (d goDetector) GetServices(dir string) []Service {
+	return getGoServices(dir)
+}
+
+func (d goDetector) TestServices(dir string) []Service {
+	return []Service{{
+		Name:        "Go Test",
+		Command:     "go test ./...",
+		Interactive: true,
+		Priority:    PriorityApp,
+	}}
+}
+
+// golangMigrateDirs are the directories golang-migrate projects usually keep
+// their migrations in.
+var golangMigrateDirs = []string{"migrations", "db/migrations"}
+
+func (d goDetector) MigrationServices(dir string) []Service {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil || !strings.Contains(string(data), "github.com/golang-migrate/migrate") {
+		return nil
+	}
+	for _, migrations := range golangMigrateDirs {
+		if fileExists(filepath.Join(dir, migrations)) {
+			return []Service{{
+				Name:     "Migrate Up",
+				Command:  `migrate -path ` + migrations + ` -database "$DATABASE_URL" up`,
+				Priority: PriorityApp,
+			}}
+		}
+	}
+	return nil
 }
 
 // --- PHP Detector Implementation ---
@@ -99,24 +157,26 @@ func (d phpDetector) Name() string {
 	return "PHP"
 }
 
-func (d phpDetector) Detect() bool {
-	_, err := os.Stat("composer.json")
-	return err == nil
+func (d phpDetector) Detect(dir string) bool {
+	return fileExists(filepath.Join(dir, "composer.json"))
 }
 
-func (d phpDetector) GetServices() []Service {
+func (d phpDetector) GetServices(dir string) []Service {
 	log.Println("Getting PHP entrypoint...")
-	contents, err := os.ReadFile("composer.json")
+	var scripts []Service
+	contents, err := os.ReadFile(filepath.Join(dir, "composer.json"))
 	if err == nil {
 		var data map[string]interface{}
 		if err := json.Unmarshal(contents, &data); err == nil {
+			scripts = composerScriptServices(data)
 			if checkDependency(data, "require-dev", "laravel/sail") || checkDependency(data, "require", "laravel/sail") {
 				log.Println("Detected Laravel Sail project")
-				return []Service{{
+				return append([]Service{{
 					Name:        "Laravel Sail",
-					Command:     "DOCKER_STREAMS=1 DOCKER_PLAIN_OUTPUT=1 script -q /dev/null ./vendor/bin/sail up",
+					Command:     "./vendor/bin/sail up",
 					Interactive: true,
-				}}
+					Priority:    PriorityStack,
+				}}, scripts...)
 			}
 		}
 	}
@@ -126,15 +186,19 @@ func (d phpDetector) GetServices() []Service {
 		"./index.php",
 	}
 	for _, entry := range commonEntrypoints {
-		if _, err := os.Stat(entry); err == nil {
+		if fileExists(filepath.Join(dir, entry)) {
 			docRoot := filepath.Dir(entry)
-			return []Service{{
+			return append([]Service{{
 				Name:        "PHP",
 				Command:     fmt.Sprintf("php -S localhost:8000 -t %s", docRoot),
 				Interactive: true,
-			}}
+				Priority:    PriorityApp,
+			}}, scripts...)
 		}
 	}
+	if len(scripts) > 0 {
+		return scripts
+	}
 	return []Service{{
 		Name:        "PHP (default)",
 		Command:     "echo 'No PHP entrypoint found. Try running the application manually.'",
@@ -142,6 +206,85 @@ func (d phpDetector) GetServices() []Service {
 	}}
 }
 
+// composerScriptServices returns a "composer <name>" service for each script
+// in a composer.json's scripts section. Event hooks such as
+// post-autoload-dump run on their own and are skipped. Scripts that look like
+// servers or watchers are interactive apps; the rest are helpers.
+func composerScriptServices(data map[string]interface{}) []Service {
+	scripts, ok := data["scripts"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(scripts))
+	for name := range scripts {
+		// An empty name can't be run with "composer <name>".
+		if name != "" && !strings.HasPrefix(name, "pre-") && !strings.HasPrefix(name, "post-") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var services []Service
+	for _, name := range names {
+		// A script is a command or a list of commands run in turn.
+		var commands []string
+		switch script := scripts[name].(type) {
+		case string:
+			commands = []string{script}
+		case []interface{}:
+			for _, command := range script {
+				if command, ok := command.(string); ok {
+					commands = append(commands, command)
+				}
+			}
+		}
+		if len(commands) == 0 {
+			continue
+		}
+		service := Service{
+			Name:        "Composer " + titleCase(name),
+			Command:     "composer " + name,
+			Interactive: looksLongRunning(name + " " + strings.Join(commands, " ")),
+			Priority:    PriorityAuxiliary,
+		}
+		if service.Interactive {
+			service.Priority = PriorityApp
+		}
+		services = append(services, service)
+	}
+	return services
+}
+
+func (d phpDetector) TestServices(dir string) []Service {
+	hasPHPUnit := fileExists(filepath.Join(dir, "phpunit.xml")) || fileExists(filepath.Join(dir, "phpunit.xml.dist"))
+	if contents, err := os.ReadFile(filepath.Join(dir, "composer.json")); err == nil {
+		var data map[string]interface{}
+		if err := json.Unmarshal(contents, &data); err == nil {
+			hasPHPUnit = hasPHPUnit || checkDependency(data, "require-dev", "phpunit/phpunit") || checkDependency(data, "require", "phpunit/phpunit")
+		}
+	}
+	if !hasPHPUnit {
+		return nil
+	}
+	return []Service{{
+		Name:        "PHPUnit",
+		Command:     "./vendor/bin/phpunit",
+		Interactive: true,
+		Priority:    PriorityApp,
+	}}
+}
+
+func (d phpDetector) MigrationServices(dir string) []Service {
+	if !fileExists(filepath.Join(dir, "artisan")) {
+		return nil
+	}
+	return []Service{{
+		Name:     "Artisan Migrate",
+		Command:  "php artisan migrate",
+		Priority: PriorityApp,
+	}}
+}
+
 // --- JavaScript Detector Implementation ---
 
 type jsDetector struct{}
@@ -150,18 +293,18 @@ func (d jsDetector) Name() string {
 	return "JavaScript"
 }
 
-func (d jsDetector) Detect() bool {
-	if _, err := os.Stat("package.json"); err == nil {
+func (d jsDetector) Detect(dir string) bool {
+	if fileExists(filepath.Join(dir, "package.json")) {
 		return true
 	}
 	// Fallback: check for any .js files.
-	jsFiles, _ := filepath.Glob("*.js")
+	jsFiles, _ := filepath.Glob(filepath.Join(dir, "*.js"))
 	return len(jsFiles) > 0
 }
 
-func (d jsDetector) GetServices() []Service {
+func (d jsDetector) GetServices(dir string) []Service {
 	var services []Service
-	data, err := ioutil.ReadFile("package.json")
+	data, err := ioutil.ReadFile(filepath.Join(dir, "package.json"))
 	if err != nil {
 		return services
 	}
@@ -169,25 +312,206 @@ func (d jsDetector) GetServices() []Service {
 	if err := json.Unmarshal(data, &pkg); err != nil {
 		return services
 	}
-	if scripts, ok := pkg["scripts"].(map[string]interface{}); ok {
-		if dev, ok := scripts["dev"].(string); ok && dev != "" {
-			services = append(services, Service{
-				Name:        "NPM Dev Script",
-				Command:     "npm run dev",
-				Interactive: true,
-			})
-		} else if start, ok := scripts["start"].(string); ok && start != "" {
+	scripts, _ := pkg["scripts"].(map[string]interface{})
+	pm := detectJSPackageManager(dir)
+	runsVite := false
+	for _, script := range runnableJSScripts {
+		if cmd, ok := scripts[script].(string); ok && cmd != "" {
 			services = append(services, Service{
-				Name:        "NPM Start",
-				Command:     "npm start",
+				Name:        jsServiceName(pm, script),
+				Command:     jsScriptCommand(pm, script),
 				Interactive: true,
+				Priority:    jsScriptPriority(script),
 			})
+			runsVite = runsVite || strings.HasPrefix(cmd, "vite")
 		}
 	}
+	// Vite projects whose scripts don't start Vite's dev server, such as
+	// libraries with only a build script, get one.
+	if !runsVite && usesVite(dir, pkg) {
+		services = append(services, Service{
+			Name:        "Vite Dev Server",
+			Command:     jsExecCommand(pm, "vite"),
+			Interactive: true,
+			Priority:    PriorityApp,
+		})
+	}
 	return services
 }
 
-// --- Helper Function ---
+// viteConfigs are the config files that mark a Vite project.
+var viteConfigs = []string{"vite.config.js", "vite.config.ts", "vite.config.mjs", "vite.config.mts", "vite.config.cjs", "vite.config.cts"}
+
+// usesVite reports whether the JavaScript project in dir, whose parsed
+// package.json is pkg, is built with Vite.
+func usesVite(dir string, pkg map[string]interface{}) bool {
+	if checkDependency(pkg, "dependencies", "vite") || checkDependency(pkg, "devDependencies", "vite") {
+		return true
+	}
+	for _, name := range viteConfigs {
+		if fileExists(filepath.Join(dir, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d jsDetector) TestServices(dir string) []Service {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil
+	}
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil
+	}
+	// npm init's placeholder only fails with "Error: no test specified".
+	test := pkg.Scripts["test"]
+	if test == "" || strings.Contains(test, "no test specified") {
+		return nil
+	}
+	pm := detectJSPackageManager(dir)
+	return []Service{{
+		Name:        jsServiceName(pm, "test"),
+		Command:     jsScriptCommand(pm, "test"),
+		Interactive: true,
+		Priority:    PriorityApp,
+	}}
+}
+
+func (d jsDetector) MigrationServices(dir string) []Service {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil
+	}
+	var pkg map[string]interface{}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil
+	}
+	if !checkDependency(pkg, "dependencies", "prisma") && !checkDependency(pkg, "devDependencies", "prisma") {
+		return nil
+	}
+	return []Service{{
+		Name:     "Prisma Migrate",
+		Command:  jsExecCommand(detectJSPackageManager(dir), "prisma migrate dev"),
+		Priority: PriorityApp,
+	}}
+}
+
+// runnableJSScripts are the package.json scripts offered as services, in
+// display order. They're all typically long-running.
+var runnableJSScripts = []string{"dev", "start", "serve", "watch", "storybook"}
+
+// jsLockfiles maps each lockfile to the package manager that writes it. Bun's
+// bunfig.toml isn't a lockfile, but marks a Bun project just as well.
+var jsLockfiles = map[string]string{
+	"package-lock.json": "npm",
+	"yarn.lock":         "yarn",
+	"pnpm-lock.yaml":    "pnpm",
+	"bun.lockb":         "bun",
+	"bun.lock":          "bun",
+	"bunfig.toml":       "bun",
+}
+
+// detectJSPackageManager returns the package manager a JavaScript project in
+// dir uses: "npm", "yarn", "pnpm" or "bun". The packageManager field in
+// package.json wins; otherwise the lockfile decides. It defaults to npm when
+// there's no lockfile or lockfiles disagree.
+func detectJSPackageManager(dir string) string {
+	if data, err := os.ReadFile(filepath.Join(dir, "package.json")); err == nil {
+		var pkg struct {
+			PackageManager string `json:"packageManager"`
+		}
+		if json.Unmarshal(data, &pkg) == nil && pkg.PackageManager != "" {
+			// The field looks like "pnpm@8.6.0".
+			name, _, _ := strings.Cut(pkg.PackageManager, "@")
+			switch name {
+			case "npm", "yarn", "pnpm", "bun":
+				return name
+			}
+		}
+	}
+
+	found := ""
+	for lockfile, pm := range jsLockfiles {
+		if !fileExists(filepath.Join(dir, lockfile)) {
+			continue
+		}
+		if found != "" && found != pm {
+			return "npm"
+		}
+		found = pm
+	}
+	if found == "" {
+		return "npm"
+	}
+	return found
+}
+
+// jsScriptPriority returns the priority of a package.json script service:
+// watchers and Storybook are helpers rather than the app itself.
+func jsScriptPriority(script string) int {
+	if script == "watch" || script == "storybook" {
+		return PriorityAuxiliary
+	}
+	return PriorityApp
+}
+
+// jsScriptCommand returns the command that runs a package.json script with
+// the given package manager.
+func jsScriptCommand(pm, script string) string {
+	switch {
+	case pm == "npm" && (script == "start" || script == "test"):
+		return "npm " + script
+	case pm == "npm", pm == "bun":
+		return pm + " run " + script
+	}
+	return pm + " " + script
+}
+
+// jsExecCommand returns the command that runs a locally installed package
+// binary with the given package manager, e.g. "npx prisma migrate dev".
+func jsExecCommand(pm, command string) string {
+	switch pm {
+	case "npm":
+		return "npx " + command
+	case "pnpm":
+		return "pnpm exec " + command
+	case "bun":
+		return "bunx " + command
+	}
+	return pm + " " + command
+}
+
+// jsServiceName returns the display name for a package.json script service,
+// e.g. "NPM Dev Script" or "Yarn Start".
+func jsServiceName(pm, script string) string {
+	label := map[string]string{"npm": "NPM", "yarn": "Yarn", "pnpm": "pnpm", "bun": "Bun"}[pm]
+	if script == "start" {
+		return label + " Start"
+	}
+	return fmt.Sprintf("%s %s Script", label, titleCase(script))
+}
+
+// --- Helper Functions ---
+
+// fileExists reports whether a file or directory exists at path.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// titleCase returns s with its first letter in upper case, for service names
+// such as "Go Worker" made from a binary or script name.
+func titleCase(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
 
 func checkDependency(data map[string]interface{}, field, dependency string) bool {
 	if deps, ok := data[field].(map[string]interface{}); ok {
@@ -199,19 +523,191 @@ func checkDependency(data map[string]interface{}, field, dependency string) bool
 
 // --- Unified Entrypoint Detection ---
 
+// GetServices returns the services defined in omnipath.yaml followed by those
+// found by the auto-detectors, ordered by priority. A configured service
+// replaces an auto-detected one with the same name, and setting
+// "override: true" in the file skips auto-detection altogether.
 func GetServices() []Service {
+	return servicesIn(".")
+}
+
+// servicesIn returns the services of the project in dir, as GetServices does
+// for the current directory.
+func servicesIn(dir string) []Service {
+	services := fileDetector{}.GetServices(dir)
+	if cfg, err := loadProjectConfig(dir); err == nil && cfg != nil && cfg.Override {
+		return services
+	}
+	configured := make(map[string]bool)
+	for _, s := range services {
+		configured[s.Name] = true
+	}
+
+	for _, d := range detectors() {
+		if d.Detect(dir) {
+			for _, s := range d.GetServices(dir) {
+				if !configured[s.Name] {
+					services = append(services, s)
+				}
+			}
+		}
+	}
+	// Stable, so services of equal priority keep the detector order.
+	sort.SliceStable(services, func(i, j int) bool {
+		return services[i].Priority > services[j].Priority
+	})
+	return services
+}
+
+// requestedServicesIn returns the services that offer finds in dir for each
+// of ds that detects a project there, ordered by priority like servicesIn.
+// It backs the services only offered on request, such as test and migration
+// services; offer returns nil for detectors without that kind of service.
+func requestedServicesIn(dir string, ds []Detector, offer func(d Detector, dir string) []Service) []Service {
 	var services []Service
-	// Include all detectors.
-	detectors := []Detector{
+	for _, d := range ds {
+		if d.Detect(dir) {
+			services = append(services, offer(d, dir)...)
+		}
+	}
+	sort.SliceStable(services, func(i, j int) bool {
+		return services[i].Priority > services[j].Priority
+	})
+	return services
+}
+
+// detectors returns every auto-detector, in the order their services are listed.
+func detectors() []Detector {
+	return []Detector{
+		procfileDetector{},
+		vscodeDetector{},
 		goDetector{},
 		phpDetector{},
 		jsDetector{},
+		denoDetector{},
+		hugoDetector{},
+		jekyllDetector{},
+		eleventyDetector{},
+		jupyterDetector{},
+		rustDetector{},
+		elixirDetector{},
+		rubyDetector{},
+		gradleDetector{},
+		mavenDetector{},
+		dockerDetector{},
+		makeDetector{},
 		// Add other detectors as needed.
 	}
-	for _, d := range detectors {
-		if d.Detect() {
-			services = append(services, d.GetServices()...)
+}
+
+// frameworkAliases maps the short names accepted by FindDetector to the name
+// of the detector they stand for.
+var frameworkAliases = map[string]string{
+	"js":   "javascript",
+	"node": "javascript",
+	"py":   "python",
+}
+
+// frameworkDetectors returns every detector that can be chosen by name: those
+// GetServices runs, followed by those only offering test or migration
+// services.
+func frameworkDetectors() []Detector {
+	all := detectors()
+	seen := make(map[string]bool)
+	for _, d := range all {
+		seen[d.Name()] = true
+	}
+	for _, d := range testDetectors() {
+		if !seen[d.Name()] {
+			seen[d.Name()] = true
+			all = append(all, d)
 		}
 	}
+	for _, d := range migrationDetectors() {
+		if !seen[d.Name()] {
+			seen[d.Name()] = true
+			all = append(all, d)
+		}
+	}
+	return all
+}
+
+// FindDetector returns the auto-detector for framework, which is matched
+// against detector names ignoring case and spaces, so "vscode" finds the VS
+// Code detector. "js" and "py" are accepted as short names.
+func FindDetector(framework string) (Detector, error) {
+	key := strings.ToLower(strings.ReplaceAll(framework, " ", ""))
+	if alias, ok := frameworkAliases[key]; ok {
+		key = alias
+	}
+	var names []string
+	for _, d := range frameworkDetectors() {
+		name := strings.ToLower(strings.ReplaceAll(d.Name(), " ", ""))
+		if name == key {
+			return d, nil
+		}
+		names = append(names, name)
+	}
+	return nil, fmt.Errorf("unknown framework %q; choose one of %s", framework, strings.Join(names, ", "))
+}
+
+// GetServicesFrom returns only the services d finds in the current directory,
+// ordered by priority, ignoring omnipath.yaml and every other detector.
+func GetServicesFrom(d Detector) []Service {
+	return servicesFrom(d, ".")
+}
+
+// GetWorkspaceServicesFrom is like GetWorkspaceServices, but only includes the
+// services d finds, as GetServicesFrom does.
+func GetWorkspaceServicesFrom(d Detector) []Service {
+	return withWorkspaces(func(dir string) []Service {
+		return servicesFrom(d, dir)
+	})
+}
+
+// DetectedInWorkspace reports whether d recognises the project in the current
+// directory or in any of its workspace members.
+func DetectedInWorkspace(d Detector) bool {
+	if d.Detect(".") {
+		return true
+	}
+	for _, dir := range Workspaces() {
+		if d.Detect(dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// servicesFrom returns the services d finds in dir, ordered by priority.
+func servicesFrom(d Detector, dir string) []Service {
+	if !d.Detect(dir) {
+		return nil
+	}
+	services := d.GetServices(dir)
+	sort.SliceStable(services, func(i, j int) bool {
+		return services[i].Priority > services[j].Priority
+	})
 	return services
 }
+
+// DetectorResult records what a single detector found, for diagnostics.
+type DetectorResult struct {
+	Name     string    `json:"name"`
+	Matched  bool      `json:"matched"`            // Whether the detector recognised the project.
+	Services []Service `json:"services,omitempty"` // The services it produced, before any omnipath.yaml overrides.
+}
+
+// Diagnose runs every detector, including the omnipath.yaml one, and reports
+// what each found without merging the results the way GetServices does.
+func Diagnose() []DetectorResult {
+	var results []DetectorResult
+	for _, d := range append([]Detector{fileDetector{}}, detectors()...) {
+		result := DetectorResult{Name: d.Name(), Matched: d.Detect(".")}
+		if result.Matched {
+			result.Services = d.GetServices(".")
+		}
+		results = append(results, result)
+	}
+	return results
+}
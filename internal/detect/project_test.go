@@ -0,0 +1,73 @@
+package detect
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestComposerScriptServicesSkipsEmptyNames(t *testing.T) {
+	data := map[string]interface{}{"scripts": map[string]interface{}{
+		"":                 "php -v",
+		"post-install-cmd": "php artisan key:generate",
+		"serve":            "php artisan serve",
+	}}
+	services := composerScriptServices(data)
+	if len(services) != 1 || services[0].Name != "Composer Serve" {
+		t.Errorf("composerScriptServices = %+v, want only Composer Serve", services)
+	}
+}
+
+func TestComposerServeScript(t *testing.T) {
+	for form, composer := range map[string]string{
+		"string": `{"scripts": {"serve": "php artisan serve"}}`,
+		"array":  `{"scripts": {"serve": ["@php artisan migrate", "php artisan serve"]}}`,
+	} {
+		var data map[string]interface{}
+		if err := json.Unmarshal([]byte(composer), &data); err != nil {
+			t.Fatal(err)
+		}
+		services := composerScriptServices(data)
+		if len(services) != 1 {
+			t.Errorf("%s form: composerScriptServices = %+v, want one service", form, services)
+			continue
+		}
+		s := services[0]
+		if s.Name != "Composer Serve" || s.Command != "composer serve" || !s.Interactive || s.Priority != PriorityApp {
+			t.Errorf("%s form: service = %+v, want an interactive Composer Serve app running composer serve", form, s)
+		}
+	}
+}
+
+func TestTitleCase(t *testing.T) {
+	for s, want := range map[string]string{"": "", "worker": "Worker", "émail": "Émail", "API": "API"} {
+		if got := titleCase(s); got != want {
+			t.Errorf("titleCase(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+// writeFiles creates files under dir, keyed by their slash-separated path
+// relative to it.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// commands returns the commands of services, in order.
+func commands(services []Service) []string {
+	out := make([]string, len(services))
+	for i, s := range services {
+		out[i] = s.Command
+	}
+	return out
+}
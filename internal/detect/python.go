@@ -0,0 +1,84 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// --- Python Detector Implementation ---
+
+// pythonDetector recognises Python projects. It only offers test and
+// migration services; it isn't one of the detectors GetServices runs.
+type pythonDetector struct{}
+
+func (d pythonDetector) Name() string {
+	return "Python"
+}
+
+func (d pythonDetector) Detect(dir string) bool {
+	for _, name := range []string{"pyproject.toml", "setup.py", "setup.cfg", "requirements.txt"} {
+		if fileExists(filepath.Join(dir, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetServices offers nothing: Python apps are run through a Procfile or
+// omnipath.yaml.
+func (d pythonDetector) GetServices(dir string) []Service {
+	return nil
+}
+
+func (d pythonDetector) MigrationServices(dir string) []Service {
+	if !fileExists(filepath.Join(dir, "manage.py")) {
+		return nil
+	}
+	return []Service{{
+		Name:     "Django Migrate",
+		Command:  "python manage.py migrate",
+		Priority: PriorityApp,
+	}}
+}
+
+func (d pythonDetector) TestServices(dir string) []Service {
+	if !usesPytest(dir) {
+		return nil
+	}
+	return []Service{{
+		Name:        "Pytest",
+		Command:     "pytest",
+		Interactive: true,
+		Priority:    PriorityApp,
+	}}
+}
+
+// usesPytest reports whether the Python project in dir is tested with pytest:
+// it has pytest's own config files, mentions pytest in its packaging or
+// requirements files, or has a tests directory, which pytest also runs when
+// the tests are written for unittest.
+func usesPytest(dir string) bool {
+	for _, name := range []string{"pytest.ini", "conftest.py", "tests"} {
+		if fileExists(filepath.Join(dir, name)) {
+			return true
+		}
+	}
+	return pythonMentions(dir, "pytest")
+}
+
+// pythonMentions reports whether the packaging or requirements files of the
+// Python project in dir mention word, typically the name of a package.
+func pythonMentions(dir, word string) bool {
+	files := []string{"pyproject.toml", "setup.cfg", "setup.py", "tox.ini", "Pipfile"}
+	requirements, _ := filepath.Glob(filepath.Join(dir, "requirements*.txt"))
+	for _, path := range requirements {
+		files = append(files, filepath.Base(path))
+	}
+	for _, name := range files {
+		if data, err := os.ReadFile(filepath.Join(dir, name)); err == nil && strings.Contains(strings.ToLower(string(data)), word) {
+			return true
+		}
+	}
+	return false
+}
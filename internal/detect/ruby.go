@@ -0,0 +1,79 @@
+package detect
+
+import "path/filepath"
+
+// --- Ruby Detector Implementation ---
+
+type rubyDetector struct{}
+
+// rubyScripts are the top-level script names run directly when a project has
+// neither Rails nor a Rack config.
+var rubyScripts = []string{"main.rb", "app.rb", "server.rb"}
+
+func (d rubyDetector) Name() string {
+	return "Ruby"
+}
+
+func (d rubyDetector) Detect(dir string) bool {
+	return fileExists(filepath.Join(dir, "Gemfile")) || fileExists(filepath.Join(dir, "config.ru"))
+}
+
+func (d rubyDetector) GetServices(dir string) []Service {
+	var services []Service
+
+	// Rails 7 apps start the server alongside asset watchers through foreman.
+	// The processes in Procfile.dev are also offered individually by the
+	// Procfile detector.
+	if fileExists(filepath.Join(dir, "bin/dev")) {
+		services = append(services, Service{
+			Name:        "Rails Dev (bin/dev)",
+			Command:     "bin/dev",
+			Interactive: true,
+			Priority:    PriorityStack,
+		})
+	}
+
+	switch {
+	case fileExists(filepath.Join(dir, "bin/rails")):
+		services = append(services, Service{
+			Name:        "Rails Server",
+			Command:     "bin/rails server",
+			Interactive: true,
+			Priority:    PriorityApp,
+		})
+	case fileExists(filepath.Join(dir, "config.ru")):
+		command := "rackup"
+		if fileExists(filepath.Join(dir, "Gemfile")) {
+			command = "bundle exec rackup"
+		}
+		services = append(services, Service{
+			Name:        "Rackup",
+			Command:     command,
+			Interactive: true,
+			Priority:    PriorityApp,
+		})
+	default:
+		for _, script := range rubyScripts {
+			if fileExists(filepath.Join(dir, script)) {
+				services = append(services, Service{
+					Name:        "Ruby (" + script + ")",
+					Command:     "ruby " + script,
+					Interactive: true,
+					Priority:    PriorityApp,
+				})
+			}
+		}
+	}
+	return services
+}
+
+func (d rubyDetector) MigrationServices(dir string) []Service {
+	if !fileExists(filepath.Join(dir, "bin/rails")) {
+		return nil
+	}
+	return []Service{{
+		Name:     "Rails Migrate",
+		Command:  "bin/rails db:migrate",
+		Priority: PriorityApp,
+	}}
+}
@@ -0,0 +1,30 @@
+package detect
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRailsServices(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"Gemfile":   "source \"https://rubygems.org\"\ngem \"rails\", \"~> 7.1\"\n",
+		"bin/rails": "#!/usr/bin/env ruby\n",
+	})
+	if !(rubyDetector{}).Detect(dir) {
+		t.Fatal("no Ruby project detected")
+	}
+	if got, want := commands(rubyDetector{}.GetServices(dir)), []string{"bin/rails server"}; !slices.Equal(got, want) {
+		t.Errorf("commands = %q, want %q", got, want)
+	}
+
+	// With bin/dev, the server is started with its asset watchers first.
+	writeFiles(t, dir, map[string]string{"bin/dev": "#!/usr/bin/env sh\nexec foreman start -f Procfile.dev\n"})
+	services := rubyDetector{}.GetServices(dir)
+	if got, want := commands(services), []string{"bin/dev", "bin/rails server"}; !slices.Equal(got, want) {
+		t.Errorf("commands with bin/dev = %q, want %q", got, want)
+	}
+	if services[0].Priority <= services[1].Priority {
+		t.Error("bin/dev doesn't outrank bin/rails server")
+	}
+}
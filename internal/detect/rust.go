@@ -0,0 +1,190 @@
+package detect
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// --- Rust Detector Implementation ---
+
+type rustDetector struct{}
+
+func (d rustDetector) Name() string {
+	return "Rust"
+}
+
+func (d rustDetector) Detect(dir string) bool {
+	return fileExists(filepath.Join(dir, "Cargo.toml"))
+}
+
+func (d rustDetector) GetServices(dir string) []Service {
+	root, err := parseCargoManifest(filepath.Join(dir, "Cargo.toml"))
+	if err != nil {
+		return nil
+	}
+
+	bins := cargoBins(dir, root)
+	for _, pattern := range root.members {
+		members, _ := filepath.Glob(filepath.Join(dir, pattern))
+		for _, member := range members {
+			if manifest, err := parseCargoManifest(filepath.Join(member, "Cargo.toml")); err == nil {
+				bins = append(bins, cargoBins(member, manifest)...)
+			}
+		}
+	}
+
+	var services []Service
+	if len(bins) > 1 {
+		for _, bin := range bins {
+			services = append(services, Service{
+				Name:        fmt.Sprintf("Cargo Run (%s)", bin),
+				Command:     "cargo run --bin " + bin,
+				Interactive: true,
+				Priority:    PriorityApp,
+			})
+		}
+	} else if len(bins) == 1 || !root.workspace {
+		services = append(services, Service{
+			Name:        "Cargo Run",
+			Command:     "cargo run",
+			Interactive: true,
+			Priority:    PriorityApp,
+		})
+	}
+
+	if root.mentionsWatch || hasCommand("cargo-watch") {
+		services = append(services, Service{
+			Name:        "Cargo Watch",
+			Command:     "cargo watch -x run",
+			Interactive: true,
+			Priority:    PriorityAuxiliary,
+		})
+	}
+	return services
+}
+
+func (d rustDetector) TestServices(dir string) []Service {
+	return []Service{{
+		Name:        "Cargo Test",
+		Command:     "cargo test",
+		Interactive: true,
+		Priority:    PriorityApp,
+	}}
+}
+
+// cargoManifest holds the parts of a Cargo.toml the detector cares about.
+type cargoManifest struct {
+	pkg           string   // [package] name.
+	bins          []string // Names of explicit [[bin]] targets.
+	workspace     bool     // Whether a [workspace] table is present.
+	members       []string // [workspace] members, possibly glob patterns.
+	mentionsWatch bool     // Whether cargo-watch is referenced anywhere.
+}
+
+// parseCargoManifest scans a Cargo.toml line by line. It only understands the
+// handful of keys needed to discover binaries, not TOML in general.
+func parseCargoManifest(path string) (cargoManifest, error) {
+	var m cargoManifest
+	file, err := os.Open(path)
+	if err != nil {
+		return m, err
+	}
+	defer file.Close()
+
+	section := ""
+	inMembers := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.Contains(line, "cargo-watch") {
+			m.mentionsWatch = true
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if inMembers {
+			m.members = append(m.members, tomlStrings(line)...)
+			inMembers = !strings.Contains(line, "]")
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			section = strings.Trim(line, "[] ")
+			if section == "workspace" {
+				m.workspace = true
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		switch {
+		case section == "package" && key == "name":
+			m.pkg = strings.Trim(value, `"'`)
+		case section == "bin" && key == "name":
+			m.bins = append(m.bins, strings.Trim(value, `"'`))
+		case section == "workspace" && key == "members":
+			m.members = append(m.members, tomlStrings(value)...)
+			inMembers = !strings.Contains(value, "]")
+		}
+	}
+	return m, scanner.Err()
+}
+
+// tomlStrings extracts the quoted strings from a fragment of a TOML array.
+func tomlStrings(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		part = strings.Trim(strings.TrimSpace(part), "[]")
+		part = strings.TrimSpace(part)
+		if len(part) >= 2 && (part[0] == '"' || part[0] == '\'') {
+			out = append(out, strings.Trim(part, `"'`))
+		}
+	}
+	return out
+}
+
+// cargoBins lists the binary targets of the crate in dir, following Cargo's
+// conventions: explicit [[bin]] targets, src/main.rs named after the package,
+// and each file or directory under src/bin.
+func cargoBins(dir string, m cargoManifest) []string {
+	seen := make(map[string]bool)
+	var bins []string
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			bins = append(bins, name)
+		}
+	}
+	for _, bin := range m.bins {
+		add(bin)
+	}
+	if m.pkg != "" {
+		if _, err := os.Stat(filepath.Join(dir, "src", "main.rs")); err == nil {
+			add(m.pkg)
+		}
+	}
+	entries, _ := os.ReadDir(filepath.Join(dir, "src", "bin"))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			if _, err := os.Stat(filepath.Join(dir, "src", "bin", entry.Name(), "main.rs")); err == nil {
+				add(entry.Name())
+			}
+		} else if strings.HasSuffix(entry.Name(), ".rs") {
+			add(strings.TrimSuffix(entry.Name(), ".rs"))
+		}
+	}
+	return bins
+}
+
+// hasCommand reports whether an executable is available on the PATH.
+func hasCommand(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
@@ -0,0 +1,59 @@
+package detect
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// cargoRunCommands returns the "cargo run" commands rustDetector offers in
+// dir, leaving out Cargo Watch, which depends on what is installed.
+func cargoRunCommands(t *testing.T, dir string) []string {
+	t.Helper()
+	if !(rustDetector{}).Detect(dir) {
+		t.Fatalf("no Rust project detected in %s", dir)
+	}
+	var runs []string
+	for _, c := range commands(rustDetector{}.GetServices(dir)) {
+		if strings.HasPrefix(c, "cargo run") {
+			runs = append(runs, c)
+		}
+	}
+	return runs
+}
+
+func TestRustSingleBinary(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"Cargo.toml":  "[package]\nname = \"app\"\nversion = \"0.1.0\"\n",
+		"src/main.rs": "fn main() {}\n",
+	})
+	if got := cargoRunCommands(t, dir); !slices.Equal(got, []string{"cargo run"}) {
+		t.Errorf("commands = %q, want [cargo run]", got)
+	}
+}
+
+func TestRustWorkspaceBinaries(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"Cargo.toml": "[workspace]\nmembers = [\n  \"crates/*\",\n]\n",
+		"crates/server/Cargo.toml": `[package]
+name = "server"
+
+[[bin]]
+name = "api"
+path = "src/api.rs"
+
+[[bin]]
+name = "worker"
+path = "src/worker.rs"
+`,
+		"crates/cli/Cargo.toml":  "[package]\nname = \"cli\"\n",
+		"crates/cli/src/main.rs": "fn main() {}\n",
+		"crates/lib/Cargo.toml":  "[package]\nname = \"lib\"\n",
+	})
+	want := []string{"cargo run --bin cli", "cargo run --bin api", "cargo run --bin worker"}
+	if got := cargoRunCommands(t, dir); !slices.Equal(got, want) {
+		t.Errorf("commands = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,105 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// --- Hugo Detector Implementation ---
+
+type hugoDetector struct{}
+
+// hugoDirs are directories of a Hugo site's layout, one of which must sit
+// next to a generic config.toml before it's taken for Hugo's.
+var hugoDirs = []string{"content", "archetypes", "layouts", "themes"}
+
+func (d hugoDetector) Name() string {
+	return "Hugo"
+}
+
+func (d hugoDetector) Detect(dir string) bool {
+	for _, name := range []string{"hugo.toml", "hugo.yaml", "hugo.json"} {
+		if fileExists(filepath.Join(dir, name)) {
+			return true
+		}
+	}
+	// Sites predating hugo.toml use config.toml.
+	if !fileExists(filepath.Join(dir, "config.toml")) {
+		return false
+	}
+	for _, name := range hugoDirs {
+		if fileExists(filepath.Join(dir, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d hugoDetector) GetServices(dir string) []Service {
+	return []Service{{
+		Name:        "Hugo Server",
+		Command:     "hugo server",
+		Interactive: true,
+		Priority:    PriorityApp,
+	}}
+}
+
+// --- Jekyll Detector Implementation ---
+
+type jekyllDetector struct{}
+
+func (d jekyllDetector) Name() string {
+	return "Jekyll"
+}
+
+// Detect requires the jekyll gem in the Gemfile, since _config.yml alone is
+// a common name for unrelated files.
+func (d jekyllDetector) Detect(dir string) bool {
+	if !fileExists(filepath.Join(dir, "_config.yml")) {
+		return false
+	}
+	gemfile, err := os.ReadFile(filepath.Join(dir, "Gemfile"))
+	return err == nil && strings.Contains(string(gemfile), "jekyll")
+}
+
+func (d jekyllDetector) GetServices(dir string) []Service {
+	return []Service{{
+		Name:        "Jekyll Serve",
+		Command:     "bundle exec jekyll serve",
+		Interactive: true,
+		Priority:    PriorityApp,
+	}}
+}
+
+// --- Eleventy Detector Implementation ---
+
+type eleventyDetector struct{}
+
+func (d eleventyDetector) Name() string {
+	return "Eleventy"
+}
+
+func (d eleventyDetector) Detect(dir string) bool {
+	for _, name := range []string{".eleventy.js", "eleventy.config.js", "eleventy.config.mjs", "eleventy.config.cjs"} {
+		if fileExists(filepath.Join(dir, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d eleventyDetector) GetServices(dir string) []Service {
+	// npx needs the package name; the other package managers run the
+	// installed binary, which is called eleventy.
+	command := "npx @11ty/eleventy --serve"
+	if pm := detectJSPackageManager(dir); pm != "npm" {
+		command = jsExecCommand(pm, "eleventy --serve")
+	}
+	return []Service{{
+		Name:        "Eleventy Serve",
+		Command:     command,
+		Interactive: true,
+		Priority:    PriorityApp,
+	}}
+}
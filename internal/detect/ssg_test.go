@@ -0,0 +1,20 @@
+package detect
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestHugoSite(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"hugo.toml":         "baseURL = 'https://example.org/'\ntitle = 'Blog'\n",
+		"content/_index.md": "# Blog\n",
+	})
+	if !(hugoDetector{}).Detect(dir) {
+		t.Fatal("no Hugo site detected from hugo.toml")
+	}
+	if got, want := commands((hugoDetector{}).GetServices(dir)), []string{"hugo server"}; !slices.Equal(got, want) {
+		t.Errorf("commands = %q, want %q", got, want)
+	}
+}
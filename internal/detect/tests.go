@@ -0,0 +1,60 @@
+package detect
+
+// testDetector is implemented by detectors that can also offer a service
+// running the project's tests. Test services are kept out of GetServices so
+// they don't crowd the usual list of apps to run.
+type testDetector interface {
+	Detector
+	TestServices(dir string) []Service
+}
+
+// testDetectors returns every detector that offers test services.
+func testDetectors() []Detector {
+	return []Detector{
+		goDetector{},
+		phpDetector{},
+		jsDetector{},
+		pythonDetector{},
+		rustDetector{},
+	}
+}
+
+// GetTestServices returns the services that run the tests of the project in
+// the current directory, such as "go test ./..." or "cargo test".
+func GetTestServices() []Service {
+	return testServicesIn(".")
+}
+
+// testServicesIn returns the test services of the project in dir, ordered
+// by priority like servicesIn.
+func testServicesIn(dir string) []Service {
+	return requestedServicesIn(dir, testDetectors(), testServicesOf)
+}
+
+// GetTestServicesFrom is like GetTestServices, but only includes the test
+// services of d, if it offers any.
+func GetTestServicesFrom(d Detector) []Service {
+	return testServicesFrom(d, ".")
+}
+
+// GetWorkspaceTestServicesFrom is like GetWorkspaceTestServices, but only
+// includes the test services of d, as GetTestServicesFrom does.
+func GetWorkspaceTestServicesFrom(d Detector) []Service {
+	return withWorkspaces(func(dir string) []Service {
+		return testServicesFrom(d, dir)
+	})
+}
+
+// testServicesFrom returns the test services d finds in dir, ordered by
+// priority.
+func testServicesFrom(d Detector, dir string) []Service {
+	return requestedServicesIn(dir, []Detector{d}, testServicesOf)
+}
+
+// testServicesOf returns the test services d offers in dir, if any.
+func testServicesOf(d Detector, dir string) []Service {
+	if td, ok := d.(testDetector); ok {
+		return td.TestServices(dir)
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package detect
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// --- VS Code Detector Implementation ---
+
+// vscodeDetector offers the tasks in .vscode/tasks.json and the launch
+// configurations in .vscode/launch.json, which often hold a project's
+// canonical run commands.
+type vscodeDetector struct{}
+
+func (d vscodeDetector) Name() string {
+	return "VS Code"
+}
+
+func (d vscodeDetector) Detect(dir string) bool {
+	return fileExists(filepath.Join(dir, ".vscode", "tasks.json")) ||
+		fileExists(filepath.Join(dir, ".vscode", "launch.json"))
+}
+
+func (d vscodeDetector) GetServices(dir string) []Service {
+	services := vscodeLaunchServices(dir)
+	return append(services, vscodeTaskServices(dir)...)
+}
+
+// vscodeTask is an entry of tasks.json.
+type vscodeTask struct {
+	Label        string            `json:"label"`
+	Type         string            `json:"type"`    // "shell", "process" or a task provider such as "npm".
+	Command      string            `json:"command"` // Shell and process tasks.
+	Script       string            `json:"script"`  // npm tasks.
+	Args         []json.RawMessage `json:"args"`    // Strings or {"value": ...} objects.
+	IsBackground bool              `json:"isBackground"`
+	Options      struct {
+		Cwd string `json:"cwd"`
+	} `json:"options"`
+}
+
+// vscodeTaskServices returns a service for each task in .vscode/tasks.json
+// that can be run outside the editor.
+func vscodeTaskServices(dir string) []Service {
+	var file struct {
+		Tasks []vscodeTask `json:"tasks"`
+	}
+	if !readJSONC(filepath.Join(dir, ".vscode", "tasks.json"), &file) {
+		return nil
+	}
+
+	var services []Service
+	for _, task := range file.Tasks {
+		var command string
+		switch task.Type {
+		case "npm":
+			if task.Script != "" {
+				command = jsScriptCommand(detectJSPackageManager(dir), task.Script)
+			}
+		case "shell", "process", "":
+			if task.Command != "" {
+				command = task.Command
+				if task.Type == "process" {
+					command = shellQuote(resolveWorkspaceFolder(command))
+				}
+				for _, arg := range task.Args {
+					command += " " + shellQuote(resolveWorkspaceFolder(vscodeArg(arg)))
+				}
+			}
+		}
+		name := task.Label
+		if name == "" {
+			name = task.Script
+		}
+		service, ok := vscodeService(name, command, task.Options.Cwd)
+		if !ok {
+			continue
+		}
+		// Background tasks are watchers and servers, which keep running.
+		service.Interactive = task.IsBackground || looksLongRunning(command)
+		service.Priority = PriorityAuxiliary
+		if service.Interactive {
+			service.Priority = PriorityApp
+		}
+		services = append(services, service)
+	}
+	return services
+}
+
+// vscodeLaunch is a configuration in launch.json.
+type vscodeLaunch struct {
+	Name              string   `json:"name"`
+	Type              string   `json:"type"`
+	Request           string   `json:"request"`
+	Program           string   `json:"program"`
+	Module            string   `json:"module"` // Python.
+	Args              []string `json:"args"`
+	Cwd               string   `json:"cwd"`
+	RuntimeExecutable string   `json:"runtimeExecutable"` // Node, e.g. "npm".
+	RuntimeArgs       []string `json:"runtimeArgs"`
+}
+
+// vscodeLaunchServices returns a service for each launch configuration in
+// .vscode/launch.json that starts a Go, Node or Python program. Attaching
+// configurations are skipped.
+func vscodeLaunchServices(dir string) []Service {
+	var file struct {
+		Configurations []vscodeLaunch `json:"configurations"`
+	}
+	if !readJSONC(filepath.Join(dir, ".vscode", "launch.json"), &file) {
+		return nil
+	}
+
+	var services []Service
+	for _, launch := range file.Configurations {
+		if launch.Request != "launch" {
+			continue
+		}
+		var parts []string
+		switch launch.Type {
+		case "go":
+			if launch.Program != "" {
+				parts = []string{"go", "run", launch.Program}
+			}
+		case "node", "pwa-node":
+			if launch.RuntimeExecutable != "" {
+				parts = append([]string{launch.RuntimeExecutable}, launch.RuntimeArgs...)
+			} else if launch.Program != "" {
+				parts = []string{"node", launch.Program}
+			}
+		case "python", "debugpy":
+			if launch.Module != "" {
+				parts = []string{"python", "-m", launch.Module}
+			} else if launch.Program != "" {
+				parts = []string{"python", launch.Program}
+			}
+		}
+		if len(parts) == 0 {
+			continue
+		}
+		var quoted []string
+		for _, part := range append(parts, launch.Args...) {
+			quoted = append(quoted, shellQuote(resolveWorkspaceFolder(part)))
+		}
+		service, ok := vscodeService(launch.Name, strings.Join(quoted, " "), launch.Cwd)
+		if !ok {
+			continue
+		}
+		service.Interactive = true
+		service.Priority = PriorityApp
+		services = append(services, service)
+	}
+	return services
+}
+
+// vscodeService returns a service running command in cwd, both of which may
+// refer to ${workspaceFolder}. It reports false if the name or command is
+// empty, or if they use other variables, such as ${file}, which only the
+// editor can resolve.
+func vscodeService(name, command, cwd string) (Service, bool) {
+	command = resolveWorkspaceFolder(command)
+	cwd = resolveWorkspaceFolder(cwd)
+	if name == "" || command == "" || strings.Contains(command+cwd, "${") {
+		return Service{}, false
+	}
+	if cwd != "" {
+		cwd = filepath.Clean(cwd)
+	}
+	if cwd == "." || filepath.IsAbs(cwd) {
+		cwd = ""
+	}
+	return Service{Name: name, Command: command, Dir: cwd}, true
+}
+
+// resolveWorkspaceFolder replaces ${workspaceFolder} with the project root,
+// which the commands are run from. Paths keep their leading "./", so that go
+// run still treats them as directories rather than import paths.
+func resolveWorkspaceFolder(s string) string {
+	s = strings.ReplaceAll(s, "${workspaceFolder}/", "./")
+	return strings.ReplaceAll(s, "${workspaceFolder}", ".")
+}
+
+// vscodeArg returns the value of a task argument, which is either a string
+// or an object with the string in its value field.
+func vscodeArg(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var quoted struct {
+		Value string `json:"value"`
+	}
+	_ = json.Unmarshal(raw, &quoted)
+	return quoted.Value
+}
+
+// longRunningWords are words in a command that suggest it keeps running, such
+// as a dev server or a watcher.
+var longRunningWords = []string{"dev", "serve", "server", "start", "watch"}
+
+// looksLongRunning reports whether command likely keeps running until stopped.
+func looksLongRunning(command string) bool {
+	for _, field := range strings.FieldsFunc(strings.ToLower(command), func(r rune) bool {
+		return !('a' <= r && r <= 'z')
+	}) {
+		for _, word := range longRunningWords {
+			if field == word {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// readJSONC decodes the JSON-with-comments file at path into v, reporting
+// whether that succeeded.
+func readJSONC(path string, v interface{}) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(stripJSONC(data), v) == nil
+}
+
+// shellQuote quotes s for sh if it contains anything other than characters
+// that are safe unquoted.
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./=:@,+%", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
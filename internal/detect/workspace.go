@@ -0,0 +1,173 @@
+package detect
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// conventionalWorkspaces are the member patterns assumed when a repository
+// doesn't declare its workspaces anywhere.
+var conventionalWorkspaces = []string{"packages/*", "apps/*"}
+
+// ScanIgnore holds glob patterns of directories that are never searched for
+// services or dependencies. A pattern matches either a directory's path or its
+// name.
+var ScanIgnore []string
+
+// GetWorkspaceServices returns the services of the project in the current
+// directory followed by those of each workspace member. Member services are
+// named "<path>/<service>", e.g. "apps/web/NPM Dev Script", and run in the
+// member's directory.
+func GetWorkspaceServices() []Service {
+	return withWorkspaces(servicesIn)
+}
+
+// GetWorkspaceTestServices is like GetWorkspaceServices, but returns test
+// services as GetTestServices does.
+func GetWorkspaceTestServices() []Service {
+	return withWorkspaces(testServicesIn)
+}
+
+// withWorkspaces returns the services find returns for the current directory
+// followed by those it returns for each workspace member, renamed and moved
+// into the member's directory.
+func withWorkspaces(find func(dir string) []Service) []Service {
+	services := find(".")
+	for _, dir := range Workspaces() {
+		for _, s := range find(dir) {
+			s.Name = filepath.ToSlash(dir) + "/" + s.Name
+			s.Dir = filepath.Join(dir, s.Dir)
+			services = append(services, s)
+		}
+	}
+	return services
+}
+
+// Workspaces returns the member directories of a monorepo rooted in the
+// current directory, sorted and relative to it. Members are read from the
+// workspaces field of package.json, pnpm-workspace.yaml and go.work; if none
+// of those declare any, the conventional packages/* and apps/* are used.
+func Workspaces() []string {
+	var patterns []string
+	patterns = append(patterns, packageJSONWorkspaces()...)
+	patterns = append(patterns, pnpmWorkspaces()...)
+	patterns = append(patterns, goWorkModules()...)
+	if len(patterns) == 0 {
+		patterns = conventionalWorkspaces
+	}
+
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, pattern := range patterns {
+		// Exclusions such as "!**/test/**" aren't supported; skipping them
+		// only means a few extra directories are searched.
+		if strings.HasPrefix(pattern, "!") {
+			continue
+		}
+		// filepath.Glob has no "**", so only direct children are matched.
+		matches, _ := filepath.Glob(strings.ReplaceAll(pattern, "**", "*"))
+		for _, match := range matches {
+			dir := filepath.Clean(match)
+			if dir == "." || seen[dir] || Ignored(dir) {
+				continue
+			}
+			if info, err := os.Stat(dir); err == nil && info.IsDir() {
+				seen[dir] = true
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// Ignored reports whether dir matches one of the ScanIgnore patterns.
+func Ignored(dir string) bool {
+	for _, pattern := range ScanIgnore {
+		if ok, _ := filepath.Match(pattern, dir); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, filepath.Base(dir)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// packageJSONWorkspaces returns the workspace patterns in package.json, which
+// npm, yarn and bun accept either as an array or as {"packages": [...]}.
+func packageJSONWorkspaces() []string {
+	data, err := os.ReadFile("package.json")
+	if err != nil {
+		return nil
+	}
+	var pkg struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	if json.Unmarshal(data, &pkg) != nil || len(pkg.Workspaces) == 0 {
+		return nil
+	}
+	var patterns []string
+	if json.Unmarshal(pkg.Workspaces, &patterns) == nil {
+		return patterns
+	}
+	var nested struct {
+		Packages []string `json:"packages"`
+	}
+	if json.Unmarshal(pkg.Workspaces, &nested) == nil {
+		return nested.Packages
+	}
+	return nil
+}
+
+// pnpmWorkspaces returns the package patterns in pnpm-workspace.yaml.
+func pnpmWorkspaces() []string {
+	data, err := os.ReadFile("pnpm-workspace.yaml")
+	if err != nil {
+		return nil
+	}
+	var cfg struct {
+		Packages []string `yaml:"packages"`
+	}
+	if yaml.Unmarshal(data, &cfg) != nil {
+		return nil
+	}
+	return cfg.Packages
+}
+
+// goWorkModules returns the module directories listed by the use directives
+// of go.work, in both the single-line and block forms.
+func goWorkModules() []string {
+	file, err := os.Open("go.work")
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var dirs []string
+	inUse := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		switch {
+		case inUse && line == ")":
+			inUse = false
+		case inUse && line != "":
+			dirs = append(dirs, strings.Trim(line, `"`))
+		case line == "use (":
+			inUse = true
+		case strings.HasPrefix(line, "use "):
+			dirs = append(dirs, strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "use ")), `"`))
+		}
+	}
+	return dirs
+}
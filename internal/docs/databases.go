@@ -0,0 +1,168 @@
+package docs
+
+import (
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/adammpkins/OmniPath/internal/envfile"
+	"gopkg.in/yaml.v3"
+)
+
+// databaseDocs maps each database system DetectDependencies recognises to
+// its official documentation.
+var databaseDocs = map[string]string{
+	"PostgreSQL": "https://www.postgresql.org/docs/current/",
+	"MySQL":      "https://dev.mysql.com/doc/",
+	"MariaDB":    "https://mariadb.com/kb/en/documentation/",
+	"MongoDB":    "https://www.mongodb.com/docs/",
+	"Redis":      "https://redis.io/docs/latest/",
+	"SQLite":     "https://www.sqlite.org/docs.html",
+}
+
+// databaseSchemes maps connection URL schemes to the database they connect to.
+var databaseSchemes = map[string]string{
+	"postgres":    "PostgreSQL",
+	"postgresql":  "PostgreSQL",
+	"mysql":       "MySQL",
+	"mariadb":     "MariaDB",
+	"mongodb":     "MongoDB",
+	"mongodb+srv": "MongoDB",
+	"redis":       "Redis",
+	"rediss":      "Redis",
+	"sqlite":      "SQLite",
+	"sqlite3":     "SQLite",
+}
+
+// databaseEnvKeys are the .env variables holding a connection URL. Laravel's
+// DB_CONNECTION names the driver instead, which databaseSchemes also covers
+// apart from its "pgsql" spelling.
+var databaseEnvKeys = []string{"DATABASE_URL", "DB_URL", "REDIS_URL", "MONGODB_URI", "MONGO_URL", "DB_CONNECTION"}
+
+// databaseImages maps Docker image names, without registry, namespace or
+// tag, to the database they run.
+var databaseImages = map[string]string{
+	"postgres":    "PostgreSQL",
+	"postgresql":  "PostgreSQL",
+	"postgis":     "PostgreSQL",
+	"mysql":       "MySQL",
+	"mariadb":     "MariaDB",
+	"mongo":       "MongoDB",
+	"mongodb":     "MongoDB",
+	"redis":       "Redis",
+	"redis-stack": "Redis",
+}
+
+// goDatabaseDrivers maps Go driver modules to their database. Unlike
+// goPackages, these only count when go.mod requires them directly, since
+// many modules pull in drivers they don't need.
+var goDatabaseDrivers = map[string]string{
+	"github.com/lib/pq":              "PostgreSQL",
+	"github.com/jackc/pgx":           "PostgreSQL",
+	"github.com/go-sql-driver/mysql": "MySQL",
+	"go.mongodb.org/mongo-driver":    "MongoDB",
+	"github.com/redis/go-redis":      "Redis",
+	"github.com/go-redis/redis":      "Redis",
+	"github.com/mattn/go-sqlite3":    "SQLite",
+	"modernc.org/sqlite":             "SQLite",
+}
+
+// composeFiles are the names Docker Compose looks for in the project root.
+var composeFiles = []string{"compose.yaml", "compose.yml", "docker-compose.yml", "docker-compose.yaml"}
+
+// detectDatabases returns the databases the project in the current
+// directory connects to, from connection URLs in .env files, Compose
+// service images, Go drivers required in go.mod, and SQLite files.
+// Drivers in other ecosystems are listed in the package tables.
+func detectDatabases(fileExtensions map[string]bool) []DependencyDocs {
+	found := make(map[string]string) // Database name to source.
+	add := func(name, source string) {
+		if _, ok := found[name]; !ok {
+			found[name] = source
+		}
+	}
+
+	for _, file := range []string{".env", ".env.example"} {
+		vars, err := envfile.Load(file)
+		if err != nil {
+			continue
+		}
+		for _, v := range vars {
+			if !slices.Contains(databaseEnvKeys, v.Key) {
+				continue
+			}
+			scheme, _, _ := strings.Cut(v.Value, ":")
+			if v.Key == "DB_CONNECTION" && scheme == "pgsql" {
+				scheme = "postgres"
+			}
+			if name, ok := databaseSchemes[strings.ToLower(scheme)]; ok {
+				add(name, v.Key+" in "+file)
+			}
+		}
+	}
+
+	for _, file := range composeFiles {
+		for _, image := range composeImages(file) {
+			if name, ok := databaseImages[imageName(image)]; ok {
+				add(name, "image "+image+" in "+file)
+			}
+		}
+	}
+
+	for _, module := range goModRequires("go.mod") {
+		for driver, name := range goDatabaseDrivers {
+			if module == driver || strings.HasPrefix(module, driver+"/") {
+				add(name, module+" in go.mod")
+			}
+		}
+	}
+
+	if fileExtensions[".sqlite"] || fileExtensions[".sqlite3"] {
+		add("SQLite", "database file by extension (.sqlite)")
+	}
+
+	var deps []DependencyDocs
+	for name, source := range found {
+		deps = append(deps, DependencyDocs{
+			Name:     name,
+			DocURL:   databaseDocs[name],
+			Source:   source,
+			Category: CategoryInfra,
+		})
+	}
+	return deps
+}
+
+// composeImages returns the images of the services in a Compose file, or
+// nil if it can't be read.
+func composeImages(file string) []string {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	var compose struct {
+		Services map[string]struct {
+			Image string `yaml:"image"`
+		} `yaml:"services"`
+	}
+	if yaml.Unmarshal(data, &compose) != nil {
+		return nil
+	}
+	var images []string
+	for _, service := range compose.Services {
+		if service.Image != "" {
+			images = append(images, service.Image)
+		}
+	}
+	return images
+}
+
+// imageName strips the registry, namespace, tag and digest from a Docker
+// image reference, so "docker.io/bitnami/postgresql:16" becomes "postgresql".
+func imageName(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	name := path.Base(image)
+	name, _, _ = strings.Cut(name, ":")
+	return strings.ToLower(name)
+}
@@ -2,17 +2,57 @@ package docs
 
 import (
 	"encoding/json"
-	"fmt"
+	"errors"
+	"io"
+	"io/fs"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 )
 
 // DependencyDocs holds information about a dependency and its documentation URL.
 type DependencyDocs struct {
-	Name   string
-	DocURL string
+	Name     string `json:"name"`
+	DocURL   string `json:"url"`
+	Source   string `json:"source,omitempty"`   // Why the dependency was detected, e.g. "from package.json".
+	Category string `json:"category,omitempty"` // One of the Category constants.
+}
+
+// Dependency categories, in the order they are listed to the user.
+const (
+	CategoryLanguage  = "language"
+	CategoryFramework = "framework"
+	CategoryLibrary   = "library"
+	CategoryTooling   = "tooling"
+	CategoryInfra     = "infra"
+)
+
+// Categories lists every dependency category in display order.
+var Categories = []string{CategoryLanguage, CategoryFramework, CategoryLibrary, CategoryTooling, CategoryInfra}
+
+// CategoryRank orders categories as listed in Categories, with unknown
+// categories last.
+func CategoryRank(category string) int {
+	for i, c := range Categories {
+		if c == category {
+			return i
+		}
+	}
+	return len(Categories)
+}
+
+// Sort orders dependencies by category, then case-insensitively by name.
+func Sort(deps []DependencyDocs) {
+	sort.SliceStable(deps, func(i, j int) bool {
+		ri, rj := CategoryRank(deps[i].Category), CategoryRank(deps[j].Category)
+		if ri != rj {
+			return ri < rj
+		}
+		return strings.ToLower(deps[i].Name) < strings.ToLower(deps[j].Name)
+	})
 }
 
 // Helper function to check if a file contains Spring annotations
@@ -46,6 +86,112 @@ func isAspNetFile(filePath string) bool {
 		strings.Contains(fileContent, "IActionResult")
 }
 
+// kubernetesKinds are the resource kinds that mark a YAML file as a
+// Kubernetes manifest rather than, say, a CI config.
+var kubernetesKinds = map[string]bool{
+	"Deployment": true, "Service": true, "StatefulSet": true, "DaemonSet": true,
+	"ReplicaSet": true, "Pod": true, "Job": true, "CronJob": true,
+	"ConfigMap": true, "Secret": true, "Ingress": true, "Namespace": true,
+	"PersistentVolume": true, "PersistentVolumeClaim": true, "ServiceAccount": true,
+	"Role": true, "RoleBinding": true, "ClusterRole": true, "ClusterRoleBinding": true,
+	"NetworkPolicy": true, "HorizontalPodAutoscaler": true, "Kustomization": true,
+}
+
+var (
+	yamlAPIVersionPattern = regexp.MustCompile(`(?m)^apiVersion:\s*\S+`)
+	yamlKindPattern       = regexp.MustCompile(`(?m)^kind:\s*["']?(\w+)`)
+)
+
+// Helper function to check if a YAML file is a Kubernetes manifest: some
+// document in it must have both an apiVersion and a known kind.
+func isKubernetesManifest(filePath string) bool {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil || !yamlAPIVersionPattern.Match(content) {
+		return false
+	}
+	for _, match := range yamlKindPattern.FindAllSubmatch(content, -1) {
+		if kubernetesKinds[string(match[1])] {
+			return true
+		}
+	}
+	return false
+}
+
+// openAPIFileNames are the conventional names of OpenAPI and Swagger specs,
+// recognised without looking inside.
+var openAPIFileNames = map[string]bool{
+	"openapi.yaml": true, "openapi.yml": true, "openapi.json": true,
+	"swagger.yaml": true, "swagger.yml": true, "swagger.json": true,
+}
+
+var openAPIYAMLPattern = regexp.MustCompile(`(?m)^["']?(openapi|swagger)["']?:\s*["']?\d`)
+
+// openAPIHeadSize is how much of a YAML or JSON file isOpenAPISpec reads.
+// Specs declare their openapi or swagger version first, so the rest of a
+// possibly huge file, such as a fixture or lock file, is never read.
+const openAPIHeadSize = 4096
+
+// isOpenAPISpec reports whether a YAML or JSON file is an OpenAPI or Swagger
+// spec, by its name or by a top-level openapi or swagger version key near the
+// start of the file.
+func isOpenAPISpec(filePath string) bool {
+	if openAPIFileNames[strings.ToLower(filepath.Base(filePath))] {
+		return true
+	}
+	f, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	head := io.LimitReader(f, openAPIHeadSize)
+	if strings.ToLower(filepath.Ext(filePath)) != ".json" {
+		content, err := io.ReadAll(head)
+		return err == nil && openAPIYAMLPattern.Match(content)
+	}
+	// Read the top-level keys one by one, stopping where the head ends.
+	dec := json.NewDecoder(head)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return false
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return false
+		}
+		if key := tok.(string); key == "openapi" || key == "swagger" {
+			return true
+		}
+		var value json.RawMessage
+		if dec.Decode(&value) != nil {
+			return false
+		}
+	}
+	return false
+}
+
+// ErrNoDependencies is returned by DetectDependencies when it recognises
+// nothing in the project.
+var ErrNoDependencies = errors.New("no known dependencies found")
+
+// packageJSONDependsOn reports whether package.json lists pkg in its
+// dependencies or devDependencies.
+func packageJSONDependsOn(pkg string) bool {
+	data, err := os.ReadFile("package.json")
+	if err != nil {
+		return false
+	}
+	var manifest struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if json.Unmarshal(data, &manifest) != nil {
+		return false
+	}
+	_, dep := manifest.Dependencies[pkg]
+	_, devDep := manifest.DevDependencies[pkg]
+	return dep || devDep
+}
+
 // DetectDependencies reads various project files
 // and returns a list of dependencies along with known documentation URLs.
 func DetectDependencies() ([]DependencyDocs, error) {
@@ -56,26 +202,38 @@ func DetectDependencies() ([]DependencyDocs, error) {
 	fileExtensions := make(map[string]bool)
 
 	// Walk the entire project directory to gather information
-	filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil // Skip files/directories we can't access
+	walkProject(".", func(path string, d fs.DirEntry) error {
+		// Xcode projects and workspaces are directories
+		if d.IsDir() {
+			dirExt := strings.ToLower(filepath.Ext(d.Name()))
+			if dirExt == ".xcodeproj" || dirExt == ".xcworkspace" {
+				depsMap["Xcode"] = DependencyDocs{
+					Name:     "Xcode",
+					DocURL:   "https://developer.apple.com/documentation/xcode",
+					Source:   "from " + path,
+					Category: CategoryTooling,
+				}
+				return filepath.SkipDir
+			}
 		}
 
-		if !info.IsDir() {
+		if !d.IsDir() {
 			// Record file extension
-			ext := strings.ToLower(filepath.Ext(info.Name()))
+			ext := strings.ToLower(filepath.Ext(d.Name()))
 			if ext != "" {
 				fileExtensions[ext] = true
 			}
 
 			// Check specific files by name or extension
-			filename := strings.ToLower(info.Name())
+			filename := strings.ToLower(d.Name())
 
 			// Ruby detection
 			if ext == ".rb" || ext == ".gemspec" || filename == "gemfile" {
 				depsMap["Ruby"] = DependencyDocs{
-					Name:   "Ruby",
-					DocURL: "https://ruby-doc.org/",
+					Name:     "Ruby",
+					DocURL:   "https://ruby-doc.org/",
+					Source:   "from " + path,
+					Category: CategoryLanguage,
 				}
 			}
 
@@ -84,8 +242,10 @@ func DetectDependencies() ([]DependencyDocs, error) {
 				content, err := ioutil.ReadFile(path)
 				if err == nil && strings.Contains(string(content), "rails") {
 					depsMap["Ruby on Rails"] = DependencyDocs{
-						Name:   "Ruby on Rails",
-						DocURL: "https://guides.rubyonrails.org/",
+						Name:     "Ruby on Rails",
+						DocURL:   "https://guides.rubyonrails.org/",
+						Source:   "rails in " + path,
+						Category: CategoryFramework,
 					}
 				}
 			}
@@ -93,8 +253,10 @@ func DetectDependencies() ([]DependencyDocs, error) {
 			// Java detection
 			if ext == ".java" || ext == ".class" || ext == ".jar" {
 				depsMap["Java"] = DependencyDocs{
-					Name:   "Java",
-					DocURL: "https://docs.oracle.com/en/java/",
+					Name:     "Java",
+					DocURL:   "https://docs.oracle.com/en/java/",
+					Source:   "from " + path,
+					Category: CategoryLanguage,
 				}
 			}
 
@@ -102,30 +264,38 @@ func DetectDependencies() ([]DependencyDocs, error) {
 			if filename == "applicationcontext.xml" || filename == "springconfig.java" ||
 				(ext == ".java" && hasSpringAnnotations(path)) {
 				depsMap["Spring"] = DependencyDocs{
-					Name:   "Spring",
-					DocURL: "https://spring.io/projects/spring-framework",
+					Name:     "Spring",
+					DocURL:   "https://spring.io/projects/spring-framework",
+					Source:   "Spring config or annotations in " + path,
+					Category: CategoryFramework,
 				}
 			}
 
 			// Maven/Gradle detection
 			if filename == "pom.xml" {
 				depsMap["Maven"] = DependencyDocs{
-					Name:   "Maven",
-					DocURL: "https://maven.apache.org/guides/",
+					Name:     "Maven",
+					DocURL:   "https://maven.apache.org/guides/",
+					Source:   "from " + path,
+					Category: CategoryTooling,
 				}
 			}
 			if filename == "build.gradle" || filename == "build.gradle.kts" {
 				depsMap["Gradle"] = DependencyDocs{
-					Name:   "Gradle",
-					DocURL: "https://docs.gradle.org/",
+					Name:     "Gradle",
+					DocURL:   "https://docs.gradle.org/",
+					Source:   "from " + path,
+					Category: CategoryTooling,
 				}
 			}
 
 			// C# detection
 			if ext == ".cs" || ext == ".csproj" || ext == ".sln" {
 				depsMap["C#"] = DependencyDocs{
-					Name:   "C#",
-					DocURL: "https://docs.microsoft.com/en-us/dotnet/csharp/",
+					Name:     "C#",
+					DocURL:   "https://docs.microsoft.com/en-us/dotnet/csharp/",
+					Source:   "from " + path,
+					Category: CategoryLanguage,
 				}
 			}
 
@@ -133,24 +303,83 @@ func DetectDependencies() ([]DependencyDocs, error) {
 			if ext == ".cshtml" || ext == ".aspx" ||
 				(ext == ".cs" && isAspNetFile(path)) {
 				depsMap["ASP.NET"] = DependencyDocs{
-					Name:   "ASP.NET",
-					DocURL: "https://docs.microsoft.com/en-us/aspnet/",
+					Name:     "ASP.NET",
+					DocURL:   "https://docs.microsoft.com/en-us/aspnet/",
+					Source:   "from " + path,
+					Category: CategoryFramework,
 				}
 			}
 
 			// TypeScript detection
 			if ext == ".ts" || ext == ".tsx" {
 				depsMap["TypeScript"] = DependencyDocs{
-					Name:   "TypeScript",
-					DocURL: "https://www.typescriptlang.org/docs/",
+					Name:     "TypeScript",
+					DocURL:   "https://www.typescriptlang.org/docs/",
+					Source:   "from " + path,
+					Category: CategoryLanguage,
 				}
 			}
 
 			// Docker detection
 			if filename == "dockerfile" || strings.HasPrefix(filename, "docker-compose") {
 				depsMap["Docker"] = DependencyDocs{
-					Name:   "Docker",
-					DocURL: "https://docs.docker.com/",
+					Name:     "Docker",
+					DocURL:   "https://docs.docker.com/",
+					Source:   "from " + path,
+					Category: CategoryInfra,
+				}
+			}
+
+			// Kubernetes and Helm detection
+			if (ext == ".yaml" || ext == ".yml") && isKubernetesManifest(path) {
+				depsMap["Kubernetes"] = DependencyDocs{
+					Name:     "Kubernetes",
+					DocURL:   "https://kubernetes.io/docs/home/",
+					Source:   "from " + path,
+					Category: CategoryInfra,
+				}
+			}
+			if _, found := depsMap["OpenAPI"]; !found && (ext == ".yaml" || ext == ".yml" || ext == ".json") && isOpenAPISpec(path) {
+				depsMap["OpenAPI"] = DependencyDocs{
+					Name:     "OpenAPI",
+					DocURL:   "https://swagger.io/docs/",
+					Source:   "from " + path,
+					Category: CategoryTooling,
+				}
+			}
+			if filename == "chart.yaml" {
+				depsMap["Helm"] = DependencyDocs{
+					Name:     "Helm",
+					DocURL:   "https://helm.sh/docs/",
+					Source:   "from " + path,
+					Category: CategoryInfra,
+				}
+			}
+
+			// CI configuration detection, by location alone
+			dir := filepath.ToSlash(filepath.Dir(path))
+			if (ext == ".yml" || ext == ".yaml") && (dir == ".github/workflows" || strings.HasSuffix(dir, "/.github/workflows")) {
+				depsMap["GitHub Actions"] = DependencyDocs{
+					Name:     "GitHub Actions",
+					DocURL:   "https://docs.github.com/en/actions",
+					Source:   "from " + path,
+					Category: CategoryTooling,
+				}
+			}
+			if filename == ".gitlab-ci.yml" {
+				depsMap["GitLab CI"] = DependencyDocs{
+					Name:     "GitLab CI",
+					DocURL:   "https://docs.gitlab.com/ee/ci/",
+					Source:   "from " + path,
+					Category: CategoryTooling,
+				}
+			}
+			if filename == "config.yml" && filepath.Base(dir) == ".circleci" {
+				depsMap["CircleCI"] = DependencyDocs{
+					Name:     "CircleCI",
+					DocURL:   "https://circleci.com/docs/",
+					Source:   "from " + path,
+					Category: CategoryTooling,
 				}
 			}
 
@@ -167,8 +396,10 @@ func DetectDependencies() ([]DependencyDocs, error) {
 						strings.Contains(htmlContent, "cdn.jsdelivr.net/npm/bootstrap") ||
 						strings.Contains(htmlContent, "stackpath.bootstrapcdn.com/bootstrap") {
 						depsMap["Bootstrap"] = DependencyDocs{
-							Name:   "Bootstrap",
-							DocURL: "https://getbootstrap.com/docs/",
+							Name:     "Bootstrap",
+							DocURL:   "https://getbootstrap.com/docs/",
+							Source:   "CDN link in " + path,
+							Category: CategoryFramework,
 						}
 					}
 
@@ -177,8 +408,10 @@ func DetectDependencies() ([]DependencyDocs, error) {
 						strings.Contains(htmlContent, "jquery.js") ||
 						strings.Contains(htmlContent, "code.jquery.com") {
 						depsMap["jQuery"] = DependencyDocs{
-							Name:   "jQuery",
-							DocURL: "https://api.jquery.com/",
+							Name:     "jQuery",
+							DocURL:   "https://api.jquery.com/",
+							Source:   "CDN link in " + path,
+							Category: CategoryLibrary,
 						}
 					}
 
@@ -187,8 +420,10 @@ func DetectDependencies() ([]DependencyDocs, error) {
 						strings.Contains(htmlContent, "fontawesome") ||
 						strings.Contains(htmlContent, "fa-") {
 						depsMap["Font Awesome"] = DependencyDocs{
-							Name:   "Font Awesome",
-							DocURL: "https://fontawesome.com/docs",
+							Name:     "Font Awesome",
+							DocURL:   "https://fontawesome.com/docs",
+							Source:   "CDN link in " + path,
+							Category: CategoryLibrary,
 						}
 					}
 
@@ -197,8 +432,10 @@ func DetectDependencies() ([]DependencyDocs, error) {
 						strings.Contains(htmlContent, "react.production.min.js") ||
 						strings.Contains(htmlContent, "react-dom") {
 						depsMap["React"] = DependencyDocs{
-							Name:   "React",
-							DocURL: "https://react.dev/reference/react",
+							Name:     "React",
+							DocURL:   "https://react.dev/reference/react",
+							Source:   "CDN link in " + path,
+							Category: CategoryFramework,
 						}
 					}
 
@@ -206,8 +443,10 @@ func DetectDependencies() ([]DependencyDocs, error) {
 					if strings.Contains(htmlContent, "vue.js") ||
 						strings.Contains(htmlContent, "vue.min.js") {
 						depsMap["Vue"] = DependencyDocs{
-							Name:   "Vue",
-							DocURL: "https://vuejs.org/guide/introduction.html",
+							Name:     "Vue",
+							DocURL:   "https://vuejs.org/guide/introduction.html",
+							Source:   "CDN link in " + path,
+							Category: CategoryFramework,
 						}
 					}
 				}
@@ -224,8 +463,10 @@ func DetectDependencies() ([]DependencyDocs, error) {
 						strings.Contains(jsContent, "ReactDOM") ||
 						strings.Contains(jsContent, "import React") {
 						depsMap["React"] = DependencyDocs{
-							Name:   "React",
-							DocURL: "https://react.dev/reference/react",
+							Name:     "React",
+							DocURL:   "https://react.dev/reference/react",
+							Source:   "used in " + path,
+							Category: CategoryFramework,
 						}
 					}
 
@@ -233,8 +474,10 @@ func DetectDependencies() ([]DependencyDocs, error) {
 					if strings.Contains(jsContent, "new Vue") ||
 						strings.Contains(jsContent, "Vue.component") {
 						depsMap["Vue"] = DependencyDocs{
-							Name:   "Vue",
-							DocURL: "https://vuejs.org/guide/introduction.html",
+							Name:     "Vue",
+							DocURL:   "https://vuejs.org/guide/introduction.html",
+							Source:   "used in " + path,
+							Category: CategoryFramework,
 						}
 					}
 
@@ -242,18 +485,22 @@ func DetectDependencies() ([]DependencyDocs, error) {
 					if strings.Contains(jsContent, "$(") ||
 						strings.Contains(jsContent, "jQuery") {
 						depsMap["jQuery"] = DependencyDocs{
-							Name:   "jQuery",
-							DocURL: "https://api.jquery.com/",
+							Name:     "jQuery",
+							DocURL:   "https://api.jquery.com/",
+							Source:   "used in " + path,
+							Category: CategoryLibrary,
 						}
 					}
 				}
 			}
 
 			// Bootstrap CSS file detection
-			if strings.Contains(strings.ToLower(info.Name()), "bootstrap") && strings.HasSuffix(strings.ToLower(info.Name()), ".css") {
+			if strings.Contains(strings.ToLower(d.Name()), "bootstrap") && strings.HasSuffix(strings.ToLower(d.Name()), ".css") {
 				depsMap["Bootstrap"] = DependencyDocs{
-					Name:   "Bootstrap",
-					DocURL: "https://getbootstrap.com/docs/",
+					Name:     "Bootstrap",
+					DocURL:   "https://getbootstrap.com/docs/",
+					Source:   "from " + path,
+					Category: CategoryFramework,
 				}
 			}
 		}
@@ -263,143 +510,361 @@ func DetectDependencies() ([]DependencyDocs, error) {
 	// Add basic language detections based on file extensions
 	if fileExtensions[".py"] {
 		depsMap["Python"] = DependencyDocs{
-			Name:   "Python",
-			DocURL: "https://docs.python.org/3/",
+			Name:     "Python",
+			DocURL:   "https://docs.python.org/3/",
+			Source:   "language by extension (.py)",
+			Category: CategoryLanguage,
 		}
 	}
 
 	if fileExtensions[".js"] {
 		depsMap["JavaScript"] = DependencyDocs{
-			Name:   "JavaScript",
-			DocURL: "https://developer.mozilla.org/en-US/docs/Web/JavaScript",
+			Name:     "JavaScript",
+			DocURL:   "https://developer.mozilla.org/en-US/docs/Web/JavaScript",
+			Source:   "language by extension (.js)",
+			Category: CategoryLanguage,
 		}
 	}
 
 	if fileExtensions[".html"] || fileExtensions[".htm"] {
 		depsMap["HTML"] = DependencyDocs{
-			Name:   "HTML",
-			DocURL: "https://developer.mozilla.org/en-US/docs/Web/HTML",
+			Name:     "HTML",
+			DocURL:   "https://developer.mozilla.org/en-US/docs/Web/HTML",
+			Source:   "language by extension (.html)",
+			Category: CategoryLanguage,
 		}
 	}
 
 	if fileExtensions[".css"] {
 		depsMap["CSS"] = DependencyDocs{
-			Name:   "CSS",
-			DocURL: "https://developer.mozilla.org/en-US/docs/Web/CSS",
+			Name:     "CSS",
+			DocURL:   "https://developer.mozilla.org/en-US/docs/Web/CSS",
+			Source:   "language by extension (.css)",
+			Category: CategoryLanguage,
 		}
 	}
 
 	if fileExtensions[".php"] {
 		depsMap["PHP"] = DependencyDocs{
-			Name:   "PHP",
-			DocURL: "https://www.php.net/docs.php",
+			Name:     "PHP",
+			DocURL:   "https://www.php.net/docs.php",
+			Source:   "language by extension (.php)",
+			Category: CategoryLanguage,
+		}
+	}
+
+	if fileExtensions[".swift"] {
+		depsMap["Swift"] = DependencyDocs{
+			Name:     "Swift",
+			DocURL:   "https://www.swift.org/documentation/",
+			Source:   "language by extension (.swift)",
+			Category: CategoryLanguage,
+		}
+	}
+
+	if fileExtensions[".nim"] || fileExtensions[".nimble"] {
+		ext := ".nim"
+		if !fileExtensions[ext] {
+			ext = ".nimble"
+		}
+		depsMap["Nim"] = DependencyDocs{
+			Name:     "Nim",
+			DocURL:   "https://nim-lang.org/documentation.html",
+			Source:   "language by extension (" + ext + ")",
+			Category: CategoryLanguage,
+		}
+	}
+
+	if fileExtensions[".cr"] {
+		depsMap["Crystal"] = DependencyDocs{
+			Name:     "Crystal",
+			DocURL:   "https://crystal-lang.org/reference/",
+			Source:   "language by extension (.cr)",
+			Category: CategoryLanguage,
+		}
+	}
+
+	if fileExtensions[".graphql"] || fileExtensions[".gql"] {
+		ext := ".graphql"
+		if !fileExtensions[ext] {
+			ext = ".gql"
+		}
+		depsMap["GraphQL"] = DependencyDocs{
+			Name:     "GraphQL",
+			DocURL:   "https://graphql.org/learn/",
+			Source:   "schema by extension (" + ext + ")",
+			Category: CategoryLanguage,
+		}
+	}
+
+	if fileExtensions[".proto"] {
+		depsMap["Protocol Buffers"] = DependencyDocs{
+			Name:     "Protocol Buffers",
+			DocURL:   "https://protobuf.dev/overview/",
+			Source:   "schema by extension (.proto)",
+			Category: CategoryLanguage,
+		}
+	}
+
+	if fileExtensions[".ipynb"] {
+		depsMap["Jupyter"] = DependencyDocs{
+			Name:     "Jupyter",
+			DocURL:   "https://docs.jupyter.org/",
+			Source:   "notebooks by extension (.ipynb)",
+			Category: CategoryTooling,
 		}
 	}
 
 	if fileExtensions[".sql"] {
 		depsMap["SQL"] = DependencyDocs{
-			Name:   "SQL",
-			DocURL: "https://www.w3schools.com/sql/",
+			Name:     "SQL",
+			DocURL:   "https://www.w3schools.com/sql/",
+			Source:   "language by extension (.sql)",
+			Category: CategoryLanguage,
 		}
 	}
 
 	// Check for specific configuration files
 	configFiles := map[string]struct {
-		path string
-		name string
-		url  string
+		path     string
+		name     string
+		url      string
+		category string
 	}{
 		"composer.json": {
-			name: "Composer",
-			url:  "https://getcomposer.org/doc/",
+			name:     "Composer",
+			url:      "https://getcomposer.org/doc/",
+			category: CategoryTooling,
+		},
+		"deno.json": {
+			name:     "Deno",
+			url:      "https://docs.deno.com/runtime/",
+			category: CategoryLanguage,
+		},
+		"deno.jsonc": {
+			name:     "Deno",
+			url:      "https://docs.deno.com/runtime/",
+			category: CategoryLanguage,
+		},
+		"bun.lockb": {
+			name:     "Bun",
+			url:      "https://bun.sh/docs",
+			category: CategoryLanguage,
+		},
+		"bun.lock": {
+			name:     "Bun",
+			url:      "https://bun.sh/docs",
+			category: CategoryLanguage,
+		},
+		"bunfig.toml": {
+			name:     "Bun",
+			url:      "https://bun.sh/docs",
+			category: CategoryLanguage,
+		},
+		"buf.yaml": {
+			name:     "Buf",
+			url:      "https://buf.build/docs/",
+			category: CategoryTooling,
+		},
+		"buf.gen.yaml": {
+			name:     "Buf",
+			url:      "https://buf.build/docs/",
+			category: CategoryTooling,
 		},
 		"go.mod": {
-			name: "Go",
-			url:  "https://golang.org/doc/",
+			name:     "Go",
+			url:      "https://golang.org/doc/",
+			category: CategoryLanguage,
 		},
 		"requirements.txt": {
-			name: "Python",
-			url:  "https://docs.python.org/3/",
+			name:     "Python",
+			url:      "https://docs.python.org/3/",
+			category: CategoryLanguage,
 		},
 		"package.json": {
-			name: "Node.js",
-			url:  "https://nodejs.org/docs/latest/api/",
+			name:     "Node.js",
+			url:      "https://nodejs.org/docs/latest/api/",
+			category: CategoryLanguage,
 		},
 		"angular.json": {
-			name: "Angular",
-			url:  "https://angular.io/docs",
+			name:     "Angular",
+			url:      "https://angular.io/docs",
+			category: CategoryFramework,
 		},
 		"vue.config.js": {
-			name: "Vue",
-			url:  "https://vuejs.org/guide/introduction.html",
+			name:     "Vue",
+			url:      "https://vuejs.org/guide/introduction.html",
+			category: CategoryFramework,
 		},
 		"tailwind.config.js": {
-			name: "Tailwind CSS",
-			url:  "https://tailwindcss.com/docs",
+			name:     "Tailwind CSS",
+			url:      "https://tailwindcss.com/docs",
+			category: CategoryFramework,
 		},
 		"nuxt.config.js": {
-			name: "Nuxt.js",
-			url:  "https://nuxtjs.org/docs/",
+			name:     "Nuxt.js",
+			url:      "https://nuxtjs.org/docs/",
+			category: CategoryFramework,
 		},
 		"next.config.js": {
-			name: "Next.js",
-			url:  "https://nextjs.org/docs/",
+			name:     "Next.js",
+			url:      "https://nextjs.org/docs/",
+			category: CategoryFramework,
 		},
 		"svelte.config.js": {
-			name: "Svelte",
-			url:  "https://svelte.dev/docs",
+			name:     "Svelte",
+			url:      "https://svelte.dev/docs",
+			category: CategoryFramework,
+		},
+		"astro.config.mjs": {
+			name:     "Astro",
+			url:      "https://docs.astro.build/",
+			category: CategoryFramework,
+		},
+		"astro.config.ts": {
+			name:     "Astro",
+			url:      "https://docs.astro.build/",
+			category: CategoryFramework,
+		},
+		"vite.config.js": {
+			name:     "Vite",
+			url:      "https://vitejs.dev/guide/",
+			category: CategoryTooling,
+		},
+		"vite.config.ts": {
+			name:     "Vite",
+			url:      "https://vitejs.dev/guide/",
+			category: CategoryTooling,
+		},
+		"vite.config.mjs": {
+			name:     "Vite",
+			url:      "https://vitejs.dev/guide/",
+			category: CategoryTooling,
+		},
+		"vite.config.mts": {
+			name:     "Vite",
+			url:      "https://vitejs.dev/guide/",
+			category: CategoryTooling,
+		},
+		"hugo.toml": {
+			name:     "Hugo",
+			url:      "https://gohugo.io/documentation/",
+			category: CategoryFramework,
+		},
+		"hugo.yaml": {
+			name:     "Hugo",
+			url:      "https://gohugo.io/documentation/",
+			category: CategoryFramework,
+		},
+		"hugo.json": {
+			name:     "Hugo",
+			url:      "https://gohugo.io/documentation/",
+			category: CategoryFramework,
+		},
+		".eleventy.js": {
+			name:     "Eleventy",
+			url:      "https://www.11ty.dev/docs/",
+			category: CategoryFramework,
+		},
+		"eleventy.config.js": {
+			name:     "Eleventy",
+			url:      "https://www.11ty.dev/docs/",
+			category: CategoryFramework,
+		},
+		"eleventy.config.mjs": {
+			name:     "Eleventy",
+			url:      "https://www.11ty.dev/docs/",
+			category: CategoryFramework,
+		},
+		"eleventy.config.cjs": {
+			name:     "Eleventy",
+			url:      "https://www.11ty.dev/docs/",
+			category: CategoryFramework,
+		},
+		"remix.config.js": {
+			name:     "Remix",
+			url:      "https://remix.run/docs",
+			category: CategoryFramework,
 		},
 		"webpack.config.js": {
-			name: "Webpack",
-			url:  "https://webpack.js.org/concepts/",
+			name:     "Webpack",
+			url:      "https://webpack.js.org/concepts/",
+			category: CategoryTooling,
 		},
 		"babel.config.js": {
-			name: "Babel",
-			url:  "https://babeljs.io/docs/",
+			name:     "Babel",
+			url:      "https://babeljs.io/docs/",
+			category: CategoryTooling,
 		},
 		"jest.config.js": {
-			name: "Jest",
-			url:  "https://jestjs.io/docs/",
+			name:     "Jest",
+			url:      "https://jestjs.io/docs/",
+			category: CategoryTooling,
 		},
 		"cypress.json": {
-			name: "Cypress",
-			url:  "https://docs.cypress.io/",
+			name:     "Cypress",
+			url:      "https://docs.cypress.io/",
+			category: CategoryTooling,
 		},
 		"tsconfig.json": {
-			name: "TypeScript",
-			url:  "https://www.typescriptlang.org/docs/",
+			name:     "TypeScript",
+			url:      "https://www.typescriptlang.org/docs/",
+			category: CategoryLanguage,
 		},
 		".eslintrc.js": {
-			name: "ESLint",
-			url:  "https://eslint.org/docs/user-guide/",
+			name:     "ESLint",
+			url:      "https://eslint.org/docs/user-guide/",
+			category: CategoryTooling,
 		},
 		".prettierrc": {
-			name: "Prettier",
-			url:  "https://prettier.io/docs/en/",
+			name:     "Prettier",
+			url:      "https://prettier.io/docs/en/",
+			category: CategoryTooling,
 		},
 		"Gemfile": {
-			name: "Ruby Bundler",
-			url:  "https://bundler.io/guides/",
+			name:     "Ruby Bundler",
+			url:      "https://bundler.io/guides/",
+			category: CategoryTooling,
 		},
 		"Pipfile": {
-			name: "Pipenv",
-			url:  "https://pipenv.pypa.io/en/latest/",
+			name:     "Pipenv",
+			url:      "https://pipenv.pypa.io/en/latest/",
+			category: CategoryTooling,
 		},
 		"poetry.lock": {
-			name: "Poetry",
-			url:  "https://python-poetry.org/docs/",
+			name:     "Poetry",
+			url:      "https://python-poetry.org/docs/",
+			category: CategoryTooling,
 		},
 		"Cargo.toml": {
-			name: "Rust",
-			url:  "https://doc.rust-lang.org/book/",
+			name:     "Rust",
+			url:      "https://doc.rust-lang.org/book/",
+			category: CategoryLanguage,
 		},
 		"mix.exs": {
-			name: "Elixir",
-			url:  "https://elixir-lang.org/docs.html",
+			name:     "Elixir",
+			url:      "https://elixir-lang.org/docs.html",
+			category: CategoryLanguage,
 		},
 		"stack.yaml": {
-			name: "Haskell",
-			url:  "https://www.haskell.org/documentation/",
+			name:     "Haskell",
+			url:      "https://www.haskell.org/documentation/",
+			category: CategoryLanguage,
+		},
+		"build.zig": {
+			name:     "Zig",
+			url:      "https://ziglang.org/documentation/master/",
+			category: CategoryLanguage,
+		},
+		"shard.yml": {
+			name:     "Crystal",
+			url:      "https://crystal-lang.org/reference/",
+			category: CategoryLanguage,
+		},
+		"Package.swift": {
+			name:     "Swift Package Manager",
+			url:      "https://www.swift.org/documentation/package-manager/",
+			category: CategoryTooling,
 		},
 	}
 
@@ -407,11 +872,8 @@ func DetectDependencies() ([]DependencyDocs, error) {
 	for fileName, info := range configFiles {
 		// Look for the config file anywhere in the project
 		var found bool
-		filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return nil
-			}
-			if !info.IsDir() && strings.EqualFold(info.Name(), fileName) {
+		walkProject(".", func(path string, d fs.DirEntry) error {
+			if !d.IsDir() && strings.EqualFold(d.Name(), fileName) {
 				found = true
 				return filepath.SkipAll
 			}
@@ -419,9 +881,29 @@ func DetectDependencies() ([]DependencyDocs, error) {
 		})
 
 		if found {
+			// SvelteKit apps have a svelte.config.js too, but need its docs
+			// rather than those of Svelte itself.
+			if fileName == "svelte.config.js" && packageJSONDependsOn("@sveltejs/kit") {
+				info.name, info.url = "SvelteKit", "https://svelte.dev/docs/kit"
+			}
 			depsMap[info.name] = DependencyDocs{
-				Name:   info.name,
-				DocURL: info.url,
+				Name:     info.name,
+				DocURL:   info.url,
+				Source:   "from " + fileName,
+				Category: info.category,
+			}
+		}
+	}
+
+	// _config.yml is too generic to map on its own; Jekyll sites also list
+	// the jekyll gem.
+	if _, err := os.Stat("_config.yml"); err == nil {
+		if gemfile, err := os.ReadFile("Gemfile"); err == nil && strings.Contains(string(gemfile), "jekyll") {
+			depsMap["Jekyll"] = DependencyDocs{
+				Name:     "Jekyll",
+				DocURL:   "https://jekyllrb.com/docs/",
+				Source:   "from _config.yml and Gemfile",
+				Category: CategoryFramework,
 			}
 		}
 	}
@@ -432,177 +914,16 @@ func DetectDependencies() ([]DependencyDocs, error) {
 		if err == nil {
 			var packageJSON map[string]interface{}
 			if err := json.Unmarshal(content, &packageJSON); err == nil {
-				// Define common npm packages and their docs
-				npmPackages := map[string]struct {
-					name string
-					url  string
-				}{
-					"express": {
-						name: "Express",
-						url:  "https://expressjs.com/en/4x/api.html",
-					},
-					"react": {
-						name: "React",
-						url:  "https://react.dev/reference/react",
-					},
-					"vue": {
-						name: "Vue",
-						url:  "https://vuejs.org/guide/introduction.html",
-					},
-					"svelte": {
-						name: "Svelte",
-						url:  "https://svelte.dev/docs",
-					},
-					"@angular/core": {
-						name: "Angular",
-						url:  "https://angular.io/docs",
-					},
-					"tailwindcss": {
-						name: "Tailwind CSS",
-						url:  "https://tailwindcss.com/docs",
-					},
-					"bootstrap": {
-						name: "Bootstrap",
-						url:  "https://getbootstrap.com/docs/",
-					},
-					"jquery": {
-						name: "jQuery",
-						url:  "https://api.jquery.com/",
-					},
-					"next": {
-						name: "Next.js",
-						url:  "https://nextjs.org/docs/",
-					},
-					"nuxt": {
-						name: "Nuxt.js",
-						url:  "https://nuxtjs.org/docs/",
-					},
-					"redux": {
-						name: "Redux",
-						url:  "https://redux.js.org/introduction/getting-started",
-					},
-					"mobx": {
-						name: "MobX",
-						url:  "https://mobx.js.org/README.html",
-					},
-					"axios": {
-						name: "Axios",
-						url:  "https://axios-http.com/docs/intro",
-					},
-					"lodash": {
-						name: "Lodash",
-						url:  "https://lodash.com/docs/",
-					},
-					"moment": {
-						name: "Moment.js",
-						url:  "https://momentjs.com/docs/",
-					},
-					"d3": {
-						name: "D3.js",
-						url:  "https://d3js.org/",
-					},
-					"three": {
-						name: "Three.js",
-						url:  "https://threejs.org/docs/",
-					},
-					"socket.io": {
-						name: "Socket.IO",
-						url:  "https://socket.io/docs/",
-					},
-					"mongoose": {
-						name: "Mongoose",
-						url:  "https://mongoosejs.com/docs/",
-					},
-					"typeorm": {
-						name: "TypeORM",
-						url:  "https://typeorm.io/",
-					},
-					"sequelize": {
-						name: "Sequelize",
-						url:  "https://sequelize.org/",
-					},
-					"prisma": {
-						name: "Prisma",
-						url:  "https://www.prisma.io/docs/",
-					},
-					"storybook": {
-						name: "Storybook",
-						url:  "https://storybook.js.org/docs/",
-					},
-					"jest": {
-						name: "Jest",
-						url:  "https://jestjs.io/docs/",
-					},
-					"mocha": {
-						name: "Mocha",
-						url:  "https://mochajs.org/",
-					},
-					"chai": {
-						name: "Chai",
-						url:  "https://www.chaijs.com/",
-					},
-					"cypress": {
-						name: "Cypress",
-						url:  "https://docs.cypress.io/",
-					},
-					"playwright": {
-						name: "Playwright",
-						url:  "https://playwright.dev/docs/intro",
-					},
-					"webpack": {
-						name: "Webpack",
-						url:  "https://webpack.js.org/concepts/",
-					},
-					"babel": {
-						name: "Babel",
-						url:  "https://babeljs.io/docs/",
-					},
-					"eslint": {
-						name: "ESLint",
-						url:  "https://eslint.org/docs/user-guide/",
-					},
-					"prettier": {
-						name: "Prettier",
-						url:  "https://prettier.io/docs/en/",
-					},
-					"sass": {
-						name: "Sass",
-						url:  "https://sass-lang.com/documentation",
-					},
-					"less": {
-						name: "Less",
-						url:  "https://lesscss.org/",
-					},
-					"styled-components": {
-						name: "styled-components",
-						url:  "https://styled-components.com/docs",
-					},
-					"emotion": {
-						name: "Emotion",
-						url:  "https://emotion.sh/docs/introduction",
-					},
-					"material-ui": {
-						name: "Material-UI",
-						url:  "https://mui.com/material-ui/getting-started/",
-					},
-					"@mui/material": {
-						name: "Material-UI",
-						url:  "https://mui.com/material-ui/getting-started/",
-					},
-					"antd": {
-						name: "Ant Design",
-						url:  "https://ant.design/docs/react/introduce",
-					},
-				}
-
 				// Check dependencies and devDependencies
 				for section := range map[string]string{"dependencies": "prod", "devDependencies": "dev"} {
 					if deps, ok := packageJSON[section].(map[string]interface{}); ok {
 						for pkgName := range deps {
 							if info, exists := npmPackages[pkgName]; exists {
-								depsMap[info.name] = DependencyDocs{
-									Name:   info.name,
-									DocURL: info.url,
+								depsMap[info.Name] = DependencyDocs{
+									Name:     info.Name,
+									DocURL:   info.URL,
+									Source:   "from package.json " + section,
+									Category: info.Category,
 								}
 							}
 						}
@@ -618,120 +939,15 @@ func DetectDependencies() ([]DependencyDocs, error) {
 		if err == nil {
 			var data map[string]interface{}
 			if err := json.Unmarshal(content, &data); err == nil {
-				// Define common PHP packages and their docs
-				phpPackages := map[string]struct {
-					name string
-					url  string
-				}{
-					"laravel/framework": {
-						name: "Laravel",
-						url:  "https://laravel.com/docs",
-					},
-					"symfony/symfony": {
-						name: "Symfony",
-						url:  "https://symfony.com/doc/current/",
-					},
-					"slim/slim": {
-						name: "Slim Framework",
-						url:  "https://www.slimframework.com/docs/",
-					},
-					"cakephp/cakephp": {
-						name: "CakePHP",
-						url:  "https://book.cakephp.org/",
-					},
-					"codeigniter/framework": {
-						name: "CodeIgniter",
-						url:  "https://codeigniter.com/user_guide/",
-					},
-					"yiisoft/yii2": {
-						name: "Yii Framework",
-						url:  "https://www.yiiframework.com/doc/guide/",
-					},
-					"laminas/laminas-mvc": {
-						name: "Laminas Framework",
-						url:  "https://docs.laminas.dev/",
-					},
-					"zendframework/zend-mvc": {
-						name: "Zend Framework",
-						url:  "https://docs.laminas.dev/",
-					},
-					"doctrine/orm": {
-						name: "Doctrine ORM",
-						url:  "https://www.doctrine-project.org/projects/doctrine-orm/en/current/index.html",
-					},
-					"illuminate/database": {
-						name: "Laravel Eloquent",
-						url:  "https://laravel.com/docs/eloquent",
-					},
-					"twig/twig": {
-						name: "Twig",
-						url:  "https://twig.symfony.com/doc/",
-					},
-					"smarty/smarty": {
-						name: "Smarty",
-						url:  "https://www.smarty.net/docs/en/",
-					},
-					"phpunit/phpunit": {
-						name: "PHPUnit",
-						url:  "https://phpunit.de/documentation.html",
-					},
-					"squizlabs/php_codesniffer": {
-						name: "PHP_CodeSniffer",
-						url:  "https://github.com/squizlabs/PHP_CodeSniffer/wiki",
-					},
-					"phpstan/phpstan": {
-						name: "PHPStan",
-						url:  "https://phpstan.org/user-guide/getting-started",
-					},
-					"nunomaduro/larastan": {
-						name: "Larastan",
-						url:  "https://github.com/nunomaduro/larastan",
-					},
-					"inertiajs/inertia-laravel": {
-						name: "InertiaJS",
-						url:  "https://inertiajs.com/",
-					},
-					"ishanvyas22/cakephp-inertiajs": {
-						name: "InertiaJS",
-						url:  "https://inertiajs.com/",
-					},
-					"inertiajs/inertia": {
-						name: "InertiaJS",
-						url:  "https://inertiajs.com/",
-					},
-					"guzzlehttp/guzzle": {
-						name: "Guzzle",
-						url:  "https://docs.guzzlephp.org/",
-					},
-					"monolog/monolog": {
-						name: "Monolog",
-						url:  "https://github.com/Seldaek/monolog/blob/main/doc/01-usage.md",
-					},
-					"league/flysystem": {
-						name: "Flysystem",
-						url:  "https://flysystem.thephpleague.com/docs/",
-					},
-					"firebase/php-jwt": {
-						name: "PHP-JWT",
-						url:  "https://github.com/firebase/php-jwt",
-					},
-					"erusev/parsedown": {
-						name: "Parsedown",
-						url:  "https://github.com/erusev/parsedown",
-					},
-					"spatie/laravel-permission": {
-						name: "Laravel Permission",
-						url:  "https://spatie.be/docs/laravel-permission/",
-					},
-				}
-
 				// Check require section
 				if req, ok := data["require"].(map[string]interface{}); ok {
 					for pkgName := range req {
 						if info, exists := phpPackages[strings.ToLower(pkgName)]; exists {
-							depsMap[info.name] = DependencyDocs{
-								Name:   info.name,
-								DocURL: info.url,
+							depsMap[info.Name] = DependencyDocs{
+								Name:     info.Name,
+								DocURL:   info.URL,
+								Source:   "from composer.json",
+								Category: info.Category,
 							}
 						}
 					}
@@ -739,8 +955,10 @@ func DetectDependencies() ([]DependencyDocs, error) {
 
 				// Add Composer to dependencies
 				depsMap["Composer"] = DependencyDocs{
-					Name:   "Composer",
-					DocURL: "https://getcomposer.org/doc/",
+					Name:     "Composer",
+					DocURL:   "https://getcomposer.org/doc/",
+					Source:   "from composer.json",
+					Category: CategoryTooling,
 				}
 			}
 		}
@@ -748,145 +966,25 @@ func DetectDependencies() ([]DependencyDocs, error) {
 
 	// Check for requirements.txt dependencies
 	if _, err := os.Stat("requirements.txt"); err == nil {
-		content, err := ioutil.ReadFile("requirements.txt")
-		if err == nil {
-			reqContent := string(content)
-			lines := strings.Split(reqContent, "\n")
-
-			// Define common Python packages and their docs
-			pythonPackages := map[string]struct {
-				name string
-				url  string
-			}{
-				"flask": {
-					name: "Flask",
-					url:  "https://flask.palletsprojects.com/",
-				},
-				"django": {
-					name: "Django",
-					url:  "https://docs.djangoproject.com/",
-				},
-				"fastapi": {
-					name: "FastAPI",
-					url:  "https://fastapi.tiangolo.com/",
-				},
-				"tornado": {
-					name: "Tornado",
-					url:  "https://www.tornadoweb.org/en/stable/",
-				},
-				"pyramid": {
-					name: "Pyramid",
-					url:  "https://docs.pylonsproject.org/projects/pyramid/",
-				},
-				"sanic": {
-					name: "Sanic",
-					url:  "https://sanic.dev/",
-				},
-				"sqlalchemy": {
-					name: "SQLAlchemy",
-					url:  "https://docs.sqlalchemy.org/",
-				},
-				"django-rest-framework": {
-					name: "Django REST Framework",
-					url:  "https://www.django-rest-framework.org/",
-				},
-				"djangorestframework": {
-					name: "Django REST Framework",
-					url:  "https://www.django-rest-framework.org/",
-				},
-				"pandas": {
-					name: "pandas",
-					url:  "https://pandas.pydata.org/docs/",
-				},
-				"numpy": {
-					name: "NumPy",
-					url:  "https://numpy.org/doc/",
-				},
-				"scipy": {
-					name: "SciPy",
-					url:  "https://docs.scipy.org/doc/scipy/",
-				},
-				"matplotlib": {
-					name: "Matplotlib",
-					url:  "https://matplotlib.org/stable/contents.html",
-				},
-				"scikit-learn": {
-					name: "scikit-learn",
-					url:  "https://scikit-learn.org/stable/user_guide.html",
-				},
-				"tensorflow": {
-					name: "TensorFlow",
-					url:  "https://www.tensorflow.org/api_docs",
-				},
-				"pytorch": {
-					name: "PyTorch",
-					url:  "https://pytorch.org/docs/stable/index.html",
-				},
-				"torch": {
-					name: "PyTorch",
-					url:  "https://pytorch.org/docs/stable/index.html",
-				},
-				"keras": {
-					name: "Keras",
-					url:  "https://keras.io/api/",
-				},
-				"requests": {
-					name: "Requests",
-					url:  "https://docs.python-requests.org/",
-				},
-				"beautifulsoup4": {
-					name: "Beautiful Soup",
-					url:  "https://www.crummy.com/software/BeautifulSoup/bs4/doc/",
-				},
-				"scrapy": {
-					name: "Scrapy",
-					url:  "https://docs.scrapy.org/",
-				},
-				"pytest": {
-					name: "pytest",
-					url:  "https://docs.pytest.org/",
-				},
-				"celery": {
-					name: "Celery",
-					url:  "https://docs.celeryq.dev/",
-				},
-				"pillow": {
-					name: "Pillow",
-					url:  "https://pillow.readthedocs.io/",
-				},
-				"opencv-python": {
-					name: "OpenCV",
-					url:  "https://docs.opencv.org/4.x/d6/d00/tutorial_py_root.html",
-				},
-			}
-
-			// Parse each line to extract package name
-			for _, line := range lines {
-				line = strings.TrimSpace(line)
-				if line == "" || strings.HasPrefix(line, "#") {
-					continue
-				}
+		requirements := parseRequirements("requirements.txt")
 
-				// Handle different requirement formats
-				parts := strings.Split(line, "==")
-				if len(parts) < 1 {
-					continue
-				}
-
-				packageName := strings.ToLower(strings.TrimSpace(parts[0]))
-				if info, exists := pythonPackages[packageName]; exists {
-					depsMap[info.name] = DependencyDocs{
-						Name:   info.name,
-						DocURL: info.url,
-					}
+		for _, req := range requirements {
+			if info, exists := pythonPackages[req.name]; exists {
+				depsMap[info.Name] = DependencyDocs{
+					Name:     info.Name,
+					DocURL:   info.URL,
+					Source:   "from " + req.file,
+					Category: info.Category,
 				}
 			}
+		}
 
-			// Add Python to dependencies
-			depsMap["Python"] = DependencyDocs{
-				Name:   "Python",
-				DocURL: "https://docs.python.org/3/",
-			}
+		// Add Python to dependencies
+		depsMap["Python"] = DependencyDocs{
+			Name:     "Python",
+			DocURL:   "https://docs.python.org/3/",
+			Source:   "from requirements.txt",
+			Category: CategoryLanguage,
 		}
 	}
 
@@ -894,8 +992,10 @@ func DetectDependencies() ([]DependencyDocs, error) {
 	if _, err := os.Stat("go.mod"); err == nil {
 		// For demonstration, we add a dependency for Go documentation.
 		depsMap["Go"] = DependencyDocs{
-			Name:   "Go",
-			DocURL: "https://golang.org/doc/",
+			Name:     "Go",
+			DocURL:   "https://golang.org/doc/",
+			Source:   "from go.mod",
+			Category: CategoryLanguage,
 		}
 
 		// Check the go.mod for Go dependencies
@@ -903,43 +1003,59 @@ func DetectDependencies() ([]DependencyDocs, error) {
 		if err == nil {
 			goModContent := string(content)
 
-			// Map of common Go packages to check for
-			goPackages := map[string]struct {
-				name string
-				url  string
-			}{
-				"github.com/gofiber/fiber": {
-					name: "Fiber",
-					url:  "https://docs.gofiber.io/",
-				},
-				"github.com/gin-gonic/gin": {
-					name: "Gin",
-					url:  "https://gin-gonic.com/docs/",
-				},
-				"github.com/gorilla/mux": {
-					name: "Gorilla Mux",
-					url:  "https://pkg.go.dev/github.com/gorilla/mux",
-				},
-				"github.com/labstack/echo": {
-					name: "Echo",
-					url:  "https://echo.labstack.com/guide/",
-				},
-				"gorm.io/gorm": {
-					name: "GORM",
-					url:  "https://gorm.io/docs/",
-				},
-				"github.com/jinzhu/gorm": {
-					name: "GORM",
-					url:  "https://gorm.io/docs/",
-				},
-			}
-
 			// Check for each Go package
 			for pkg, info := range goPackages {
 				if strings.Contains(goModContent, pkg) {
-					depsMap[info.name] = DependencyDocs{
-						Name:   info.name,
-						DocURL: info.url,
+					depsMap[info.Name] = DependencyDocs{
+						Name:     info.Name,
+						DocURL:   info.URL,
+						Source:   "from go.mod",
+						Category: info.Category,
+					}
+				}
+			}
+		}
+	}
+
+	// Check for Package.swift dependencies
+	if _, err := os.Stat("Package.swift"); err == nil {
+		content, err := ioutil.ReadFile("Package.swift")
+		if err == nil {
+			// Split on .package( so each entry, even one spread over several
+			// lines, can be matched without parsing the Swift DSL
+			entries := strings.Split(strings.ToLower(string(content)), ".package(")
+			for _, entry := range entries[1:] {
+				for repo, info := range swiftPackages {
+					if strings.Contains(entry, repo) {
+						depsMap[info.Name] = DependencyDocs{
+							Name:     info.Name,
+							DocURL:   info.URL,
+							Source:   "from Package.swift",
+							Category: info.Category,
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Check for mix.exs dependencies
+	if _, err := os.Stat("mix.exs"); err == nil {
+		content, err := ioutil.ReadFile("mix.exs")
+		if err == nil {
+			// Only look at the deps function so project metadata isn't matched
+			mixContent := string(content)
+			if i := strings.Index(mixContent, "defp deps"); i >= 0 {
+				mixContent = mixContent[i:]
+			}
+
+			for pkg, info := range hexPackages {
+				if regexp.MustCompile(`\{\s*:` + regexp.QuoteMeta(pkg) + `\s*,`).MatchString(mixContent) {
+					depsMap[info.Name] = DependencyDocs{
+						Name:     info.Name,
+						DocURL:   info.URL,
+						Source:   "from mix.exs",
+						Category: info.Category,
 					}
 				}
 			}
@@ -948,11 +1064,8 @@ func DetectDependencies() ([]DependencyDocs, error) {
 
 	// Check for main.py and other Python-specific files
 	hasPythonMain := false
-	filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil // Skip files/directories we can't access
-		}
-		if !info.IsDir() && info.Name() == "main.py" {
+	walkProject(".", func(path string, d fs.DirEntry) error {
+		if !d.IsDir() && d.Name() == "main.py" {
 			hasPythonMain = true
 			return filepath.SkipAll // Stop searching once we find it
 		}
@@ -961,26 +1074,33 @@ func DetectDependencies() ([]DependencyDocs, error) {
 
 	if hasPythonMain {
 		depsMap["Python"] = DependencyDocs{
-			Name:   "Python",
-			DocURL: "https://docs.python.org/3/",
+			Name:     "Python",
+			DocURL:   "https://docs.python.org/3/",
+			Source:   "from main.py",
+			Category: CategoryLanguage,
 		}
 	}
 
 	// Additional check for Bootstrap - recursively search for bootstrap CSS files
-	filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil // Skip files/directories we can't access
-		}
-		if !info.IsDir() && strings.Contains(strings.ToLower(info.Name()), "bootstrap") && strings.HasSuffix(strings.ToLower(info.Name()), ".css") {
+	walkProject(".", func(path string, d fs.DirEntry) error {
+		if !d.IsDir() && strings.Contains(strings.ToLower(d.Name()), "bootstrap") && strings.HasSuffix(strings.ToLower(d.Name()), ".css") {
 			depsMap["Bootstrap"] = DependencyDocs{
-				Name:   "Bootstrap",
-				DocURL: "https://getbootstrap.com/docs/",
+				Name:     "Bootstrap",
+				DocURL:   "https://getbootstrap.com/docs/",
+				Source:   "from " + path,
+				Category: CategoryFramework,
 			}
 			return filepath.SkipAll // Stop searching once we find one
 		}
 		return nil
 	})
 
+	for _, db := range detectDatabases(fileExtensions) {
+		if _, exists := depsMap[db.Name]; !exists {
+			depsMap[db.Name] = db
+		}
+	}
+
 	// Convert map to slice
 	var deps []DependencyDocs
 	for _, dep := range depsMap {
@@ -988,7 +1108,7 @@ func DetectDependencies() ([]DependencyDocs, error) {
 	}
 
 	if len(deps) == 0 {
-		return nil, fmt.Errorf("no known dependencies found")
+		return nil, ErrNoDependencies
 	}
 
 	return deps, nil
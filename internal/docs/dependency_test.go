@@ -0,0 +1,37 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsOpenAPISpec(t *testing.T) {
+	padding := strings.Repeat("x", 2*openAPIHeadSize)
+	tests := []struct {
+		name, content string
+		want          bool
+	}{
+		{"openapi.yaml", "not even yaml", true},
+		{"api.yaml", "openapi: 3.0.3\ninfo:\n  title: Pets\n", true},
+		{"api.yml", "swagger: '2.0'\n", true},
+		{"values.yaml", "image: nginx\nopenapi: false\n", false},
+		{"api.json", `{"openapi": "3.1.0", "paths": {}}`, true},
+		{"api.json", `{"info": {"title": "Pets"}, "swagger": "2.0"}`, true},
+		{"nested.json", `{"components": {"openapi": "3.1.0"}}`, false},
+		{"array.json", `[{"openapi": "3.1.0"}]`, false},
+		{"big.json", `{"openapi": "3.1.0", "description": "` + padding + `"}`, true},
+		{"late.json", `{"description": "` + padding + `", "openapi": "3.1.0"}`, false},
+		{"late.yaml", "description: " + padding + "\nopenapi: 3.0.3\n", false},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), tt.name)
+		if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if got := isOpenAPISpec(path); got != tt.want {
+			t.Errorf("isOpenAPISpec(%s %.40q) = %v, want %v", tt.name, tt.content, got, tt.want)
+		}
+	}
+}
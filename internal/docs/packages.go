@@ -0,0 +1,81 @@
+package docs
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"path"
+	"slices"
+)
+
+// packageFiles holds the package documentation tables, one JSON file per
+// package ecosystem. See packages/README.md for their schema.
+//
+//go:embed packages/*.json
+var packageFiles embed.FS
+
+// packageDoc describes the documentation of a package in a package table.
+type packageDoc struct {
+	Name     string `json:"name"`     // Display name, shared by packages documented together.
+	URL      string `json:"url"`      // Documentation URL.
+	Category string `json:"category"` // One of Categories.
+}
+
+// The package tables, keyed by the name a manifest uses for the package.
+// Composer and Swift keys are lowercase, as manifests are matched
+// case-insensitively.
+var (
+	npmPackages    = mustLoadPackages("npm.json")      // package.json dependencies.
+	phpPackages    = mustLoadPackages("composer.json") // composer.json require.
+	pythonPackages = mustLoadPackages("pypi.json")     // Normalized requirements.txt names.
+	goPackages     = mustLoadPackages("go.json")       // Module path prefixes in go.mod.
+	swiftPackages  = mustLoadPackages("swift.json")    // Repository paths in Package.swift.
+	hexPackages    = mustLoadPackages("hex.json")      // mix.exs deps.
+)
+
+// mustLoadPackages loads an embedded package table. The tables ship with the
+// binary, so a malformed one is a bug that should fail loudly at startup.
+func mustLoadPackages(name string) map[string]packageDoc {
+	table, err := loadPackages(packageFiles, path.Join("packages", name))
+	if err != nil {
+		panic(err)
+	}
+	return table
+}
+
+// loadPackages reads and validates the package table at name in fsys.
+func loadPackages(fsys fs.FS, name string) (map[string]packageDoc, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var table map[string]packageDoc
+	if err := dec.Decode(&table); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", name, err)
+	}
+	for pkg, doc := range table {
+		if err := doc.validate(); err != nil {
+			return nil, fmt.Errorf("%s: package %q: %w", name, pkg, err)
+		}
+	}
+	return table, nil
+}
+
+// validate reports the first problem with d, if any.
+func (d packageDoc) validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("missing name")
+	}
+	if u, err := url.Parse(d.URL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("url %q is not an absolute http(s) URL", d.URL)
+	}
+	if !slices.Contains(Categories, d.Category) {
+		return fmt.Errorf("unknown category %q", d.Category)
+	}
+	return nil
+}
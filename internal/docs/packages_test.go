@@ -0,0 +1,50 @@
+package docs
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestPackageFiles(t *testing.T) {
+	names, err := fs.Glob(packageFiles, "packages/*.json")
+	if err != nil || len(names) == 0 {
+		t.Fatalf("no package tables embedded: %v", err)
+	}
+	for _, name := range names {
+		table, err := loadPackages(packageFiles, name)
+		if err != nil {
+			t.Errorf("loadPackages: %v", err)
+			continue
+		}
+		if len(table) == 0 {
+			t.Errorf("%s lists no packages", name)
+		}
+		// Composer and Swift manifests are matched case-insensitively.
+		if base := path.Base(name); base == "composer.json" || base == "swift.json" {
+			for pkg := range table {
+				if pkg != strings.ToLower(pkg) {
+					t.Errorf("%s: package %q isn't lowercase", name, pkg)
+				}
+			}
+		}
+	}
+}
+
+func TestLoadPackagesRejectsBadEntries(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":    `{"react": {"name": "React", "url": "https://react.dev", "category": "framework", "docs": "x"}}`,
+		"missing name":     `{"react": {"url": "https://react.dev", "category": "framework"}}`,
+		"relative url":     `{"react": {"name": "React", "url": "react.dev", "category": "framework"}}`,
+		"unknown category": `{"react": {"name": "React", "url": "https://react.dev", "category": "ui"}}`,
+		"not an object":    `["react"]`,
+	}
+	for problem, content := range tests {
+		fsys := fstest.MapFS{"table.json": {Data: []byte(content)}}
+		if _, err := loadPackages(fsys, "table.json"); err == nil {
+			t.Errorf("loadPackages accepted a table with %s", problem)
+		}
+	}
+}
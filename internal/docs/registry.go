@@ -0,0 +1,215 @@
+package docs
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DetectAllDependencies is like DetectDependencies, but also lists the
+// packages in package.json, composer.json, requirements.txt, go.mod, *.nimble
+// and shard.yml that the built-in tables don't know, linked to their page on
+// the ecosystem's package registry.
+func DetectAllDependencies() ([]DependencyDocs, error) {
+	deps, err := DetectDependencies()
+	if err != nil && !errors.Is(err, ErrNoDependencies) {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, dep := range deps {
+		seen[dep.Name] = true
+	}
+	for _, dep := range registryDependencies() {
+		if !seen[dep.Name] {
+			seen[dep.Name] = true
+			deps = append(deps, dep)
+		}
+	}
+	if len(deps) == 0 {
+		return nil, ErrNoDependencies
+	}
+	return deps, nil
+}
+
+// registryDependencies returns a registry link for every package declared in
+// the current directory's manifests that has no entry in the package tables.
+func registryDependencies() []DependencyDocs {
+	var deps []DependencyDocs
+	add := func(name, url, source string) {
+		deps = append(deps, DependencyDocs{
+			Name:     name,
+			DocURL:   url,
+			Source:   source + " (registry)",
+			Category: CategoryLibrary,
+		})
+	}
+
+	var packageJSON struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if readJSON("package.json", &packageJSON) {
+		for section, pkgs := range map[string]map[string]string{"dependencies": packageJSON.Dependencies, "devDependencies": packageJSON.DevDependencies} {
+			for pkg := range pkgs {
+				if _, known := npmPackages[pkg]; !known {
+					add(pkg, "https://www.npmjs.com/package/"+pkg, "from package.json "+section)
+				}
+			}
+		}
+	}
+
+	var composerJSON struct {
+		Require map[string]string `json:"require"`
+	}
+	if readJSON("composer.json", &composerJSON) {
+		for pkg := range composerJSON.Require {
+			// "php" and "ext-*" are platform requirements, not packages.
+			pkg = strings.ToLower(pkg)
+			if _, known := phpPackages[pkg]; !known && strings.Contains(pkg, "/") {
+				add(pkg, "https://packagist.org/packages/"+pkg, "from composer.json")
+			}
+		}
+	}
+
+	for _, req := range parseRequirements("requirements.txt") {
+		if _, known := pythonPackages[req.name]; !known {
+			add(req.name, "https://pypi.org/project/"+req.name+"/", "from "+req.file)
+		}
+	}
+
+	for _, module := range goModRequires("go.mod") {
+		if !knownGoModule(module) {
+			add(module, "https://pkg.go.dev/"+module, "from go.mod")
+		}
+	}
+
+	// Nim and Crystal have no package tables, so every package is linked.
+	nimbleFiles, _ := filepath.Glob("*.nimble")
+	for _, file := range nimbleFiles {
+		for _, pkg := range nimbleRequires(file) {
+			add(pkg, "https://nimble.directory/pkg/"+pkg, "from "+file)
+		}
+	}
+
+	for _, shard := range shardDependencies("shard.yml") {
+		add(shard, "https://shardbox.org/shards/"+shard, "from shard.yml")
+	}
+	return deps
+}
+
+// nimbleRequiresPattern matches the quoted requirements of a .nimble file's
+// requires lines, such as "jester >= 0.5.0".
+var nimbleRequiresPattern = regexp.MustCompile(`"([^"]+)"`)
+
+// nimbleRequires returns the packages a .nimble file requires, leaving out
+// the Nim compiler itself.
+func nimbleRequires(path string) []string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var pkgs []string
+	for _, line := range strings.Split(string(content), "\n") {
+		rest, ok := strings.CutPrefix(strings.TrimSpace(line), "requires")
+		if !ok {
+			continue
+		}
+		for _, m := range nimbleRequiresPattern.FindAllStringSubmatch(rest, -1) {
+			fields := strings.Fields(m[1])
+			if len(fields) == 0 || strings.EqualFold(fields[0], "nim") {
+				continue
+			}
+			pkgs = append(pkgs, strings.ToLower(fields[0]))
+		}
+	}
+	return pkgs
+}
+
+// shardDependencies returns the shards a shard.yml file depends on, including
+// its development dependencies.
+func shardDependencies(path string) []string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var shard struct {
+		Dependencies            map[string]yaml.Node `yaml:"dependencies"`
+		DevelopmentDependencies map[string]yaml.Node `yaml:"development_dependencies"`
+	}
+	if yaml.Unmarshal(content, &shard) != nil {
+		return nil
+	}
+	var names []string
+	for name := range shard.Dependencies {
+		names = append(names, name)
+	}
+	for name := range shard.DevelopmentDependencies {
+		names = append(names, name)
+	}
+	return names
+}
+
+// readJSON decodes the JSON file at path into v, reporting whether that
+// succeeded.
+func readJSON(path string, v interface{}) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+// goModRequires returns the modules a go.mod file requires directly, in both
+// the single-line and block forms of the require directive. Modules marked
+// "// indirect" are skipped.
+func goModRequires(path string) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var modules []string
+	inBlock := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "require (":
+			inBlock = true
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimPrefix(line, "require ")
+		case !inBlock:
+			continue
+		}
+		if strings.Contains(line, "// indirect") {
+			continue
+		}
+		if fields := strings.Fields(line); len(fields) >= 2 && !strings.HasPrefix(fields[0], "//") {
+			modules = append(modules, fields[0])
+		}
+	}
+	return modules
+}
+
+// knownGoModule reports whether module is covered by the Go package table,
+// which matches module path prefixes such as github.com/labstack/echo for
+// github.com/labstack/echo/v4.
+func knownGoModule(module string) bool {
+	for prefix := range goPackages {
+		if strings.HasPrefix(module, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// requirement is a package listed in a pip requirements file.
+type requirement struct {
+	name string // Normalized package name, e.g. "scikit-learn".
+	file string // The requirements file that lists it.
+}
+
+var (
+	// requirementName matches the PEP 508 project name at the start of a
+	// requirement, before any extras, version specifiers or markers.
+	requirementName = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9._-]*[A-Za-z0-9])?`)
+	// eggFragment matches the #egg=name fragment of an editable VCS URL.
+	eggFragment = regexp.MustCompile(`#egg=([A-Za-z0-9._-]+)`)
+	// nameSeparators are collapsed to "-" when normalizing names (PEP 503).
+	nameSeparators = regexp.MustCompile(`[-_.]+`)
+)
+
+// parseRequirements returns the packages listed in a pip requirements file,
+// following "-r other.txt" includes relative to the including file. Extras,
+// version specifiers, environment markers and comments are stripped, and
+// names are normalized so that e.g. Scikit_Learn matches scikit-learn.
+func parseRequirements(path string) []requirement {
+	return parseRequirementsFile(path, make(map[string]bool))
+}
+
+func parseRequirementsFile(path string, seen map[string]bool) []requirement {
+	clean := filepath.Clean(path)
+	if seen[clean] {
+		return nil
+	}
+	seen[clean] = true
+	content, err := os.ReadFile(clean)
+	if err != nil {
+		return nil
+	}
+
+	// Lines ending in a backslash continue on the next line.
+	source := strings.ReplaceAll(string(content), "\\\r\n", "")
+	source = strings.ReplaceAll(source, "\\\n", "")
+
+	var reqs []requirement
+	for _, line := range strings.Split(source, "\n") {
+		// A comment starts at "#" at the beginning of a line or after
+		// whitespace; "#egg=" in a URL isn't one.
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if include, ok := requirementOption(line, "-r", "--requirement"); ok {
+			reqs = append(reqs, parseRequirementsFile(filepath.Join(filepath.Dir(clean), include), seen)...)
+			continue
+		}
+		if editable, ok := requirementOption(line, "-e", "--editable"); ok {
+			// Only VCS URLs name their package, through #egg=.
+			if m := eggFragment.FindStringSubmatch(editable); m != nil {
+				reqs = append(reqs, requirement{name: normalizeRequirement(m[1]), file: clean})
+			}
+			continue
+		}
+		// Other options, such as --index-url or -c constraints files, don't
+		// list packages.
+		if strings.HasPrefix(line, "-") {
+			continue
+		}
+		if name := requirementName.FindString(line); name != "" {
+			reqs = append(reqs, requirement{name: normalizeRequirement(name), file: clean})
+		}
+	}
+	return reqs
+}
+
+// requirementOption returns the value of a requirements file option given in
+// its short or long form, e.g. "-r base.txt", "-rbase.txt" or
+// "--requirement=base.txt".
+func requirementOption(line, short, long string) (string, bool) {
+	for _, prefix := range []string{long + "=", long + " ", short + " ", short} {
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(line[len(prefix):]), true
+		}
+	}
+	return "", false
+}
+
+// normalizeRequirement normalizes a package name as PEP 503 does.
+func normalizeRequirement(name string) string {
+	return nameSeparators.ReplaceAllString(strings.ToLower(name), "-")
+}
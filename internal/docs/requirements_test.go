@@ -0,0 +1,64 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseRequirements(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"requirements.txt": `# Web
+-r base.txt
+uvicorn[standard]>=0.20
+django>=4; python_version>='3.8'
+Scikit_Learn==1.4 \
+    --hash=sha256:abc
+--index-url https://pypi.example.com/simple
+-e git+https://github.com/psf/requests.git#egg=requests
+requests  # already included, listed again
+`,
+		"base.txt": `-rrequirements.txt
+numpy
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reqs := parseRequirements(filepath.Join(dir, "requirements.txt"))
+	want := []requirement{
+		{name: "numpy", file: filepath.Join(dir, "base.txt")},
+		{name: "uvicorn", file: filepath.Join(dir, "requirements.txt")},
+		{name: "django", file: filepath.Join(dir, "requirements.txt")},
+		{name: "scikit-learn", file: filepath.Join(dir, "requirements.txt")},
+		{name: "requests", file: filepath.Join(dir, "requirements.txt")},
+		{name: "requests", file: filepath.Join(dir, "requirements.txt")},
+	}
+	if len(reqs) != len(want) {
+		t.Fatalf("parseRequirements = %v, want %v", reqs, want)
+	}
+	for i := range want {
+		if reqs[i] != want[i] {
+			t.Errorf("requirement %d = %v, want %v", i, reqs[i], want[i])
+		}
+	}
+}
+
+func TestRequirementName(t *testing.T) {
+	tests := map[string]string{
+		"uvicorn[standard]>=0.20":           "uvicorn",
+		"django>=4; python_version>='3.8'":  "django",
+		"Flask":                             "Flask",
+		"zope.interface~=6.0":               "zope.interface",
+		"pkg @ https://example.com/pkg.whl": "pkg",
+	}
+	for line, want := range tests {
+		if got := requirementName.FindString(line); got != want {
+			t.Errorf("requirementName in %q = %q, want %q", line, got, want)
+		}
+	}
+}
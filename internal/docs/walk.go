@@ -0,0 +1,125 @@
+package docs
+
+import (
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/adammpkins/OmniPath/internal/detect"
+)
+
+// MaxDepth limits how many directory levels below the project root
+// dependency detection scans, like find's -maxdepth: 1 scans only the root's
+// own entries. Zero means no limit.
+var MaxDepth int
+
+// walkProject calls fn for every file and directory under root, like
+// filepath.WalkDir, but follows symlinked directories that resolve inside
+// root, visiting each real directory once so symlink cycles end. Symlinks to
+// directories outside root, such as a home directory, aren't followed, and
+// directories matching the scan_ignore setting (see detect.Ignored) are
+// skipped. Paths that can't be read are skipped and logged at debug level
+// instead of stopping the walk. fn may return filepath.SkipDir or
+// filepath.SkipAll as with filepath.WalkDir. Directories MaxDepth levels
+// deep are passed to fn but not read.
+func walkProject(root string, fn func(path string, d fs.DirEntry) error) error {
+	info, err := os.Stat(root)
+	if err != nil {
+		slog.Debug("skipping unreadable path", "path", root, "err", err)
+		return nil
+	}
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err == nil {
+		realRoot, err = filepath.Abs(realRoot)
+	}
+	if err != nil {
+		slog.Debug("skipping unreadable path", "path", root, "err", err)
+		return nil
+	}
+	w := &walker{fn: fn, root: realRoot, visited: map[string]bool{}}
+	err = w.walk(root, fs.FileInfoToDirEntry(info), 0)
+	if errors.Is(err, filepath.SkipDir) || errors.Is(err, filepath.SkipAll) {
+		return nil
+	}
+	return err
+}
+
+type walker struct {
+	fn      func(path string, d fs.DirEntry) error
+	root    string          // Absolute real path of the project root.
+	visited map[string]bool // Keys of the directories already walked.
+}
+
+func (w *walker) walk(path string, d fs.DirEntry, depth int) error {
+	if err := w.fn(path, d); err != nil || !d.IsDir() {
+		return err
+	}
+	if MaxDepth > 0 && depth >= MaxDepth {
+		slog.Debug("not descending past --max-depth", "path", path)
+		return nil
+	}
+
+	key, err := dirKey(path)
+	if err != nil {
+		slog.Debug("skipping unreadable directory", "path", path, "err", err)
+		return nil
+	}
+	if w.visited[key] {
+		slog.Debug("skipping directory already walked", "path", path)
+		return nil
+	}
+	w.visited[key] = true
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		slog.Debug("skipping unreadable directory", "path", path, "err", err)
+		return nil
+	}
+	for _, entry := range entries {
+		child := filepath.Join(path, entry.Name())
+		if entry.Type()&fs.ModeSymlink != 0 {
+			info, err := os.Stat(child)
+			if err != nil {
+				slog.Debug("skipping broken symlink", "path", child, "err", err)
+				continue
+			}
+			if info.IsDir() && !w.inside(child) {
+				slog.Debug("skipping symlink to a directory outside the project", "path", child)
+				continue
+			}
+			// Stat names the entry after the link, not its target.
+			entry = fs.FileInfoToDirEntry(info)
+		}
+		if entry.IsDir() && detect.Ignored(child) {
+			slog.Debug("skipping directory in scan_ignore", "path", child)
+			continue
+		}
+		if err := w.walk(child, entry, depth+1); err != nil {
+			if errors.Is(err, filepath.SkipDir) && entry.IsDir() {
+				continue
+			}
+			if errors.Is(err, filepath.SkipDir) {
+				// SkipDir from a file skips the rest of its directory.
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// inside reports whether path resolves to a location within the root.
+func (w *walker) inside(path string) bool {
+	real, err := filepath.EvalSymlinks(path)
+	if err == nil {
+		real, err = filepath.Abs(real)
+	}
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(w.root, real)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,162 @@
+package docs
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/adammpkins/OmniPath/internal/detect"
+)
+
+func symlink(t *testing.T, target, link string) {
+	t.Helper()
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+}
+
+func writeFile(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWalkProjectSymlinks(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	writeFile(t, filepath.Join(root, "package.json"))
+	writeFile(t, filepath.Join(root, "web", "package.json"))
+	writeFile(t, filepath.Join(root, "shared", "composer.json"))
+	writeFile(t, filepath.Join(outside, "go.mod"))
+
+	// A cycle back to the root, a second way into a directory already walked,
+	// and a link out of the project.
+	symlink(t, "..", filepath.Join(root, "web", "root"))
+	symlink(t, filepath.Join(root, "shared"), filepath.Join(root, "web", "shared"))
+	symlink(t, outside, filepath.Join(root, "home"))
+
+	var files []string
+	err := walkProject(root, func(path string, d fs.DirEntry) error {
+		if !d.IsDir() {
+			rel, _ := filepath.Rel(root, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walkProject: %v", err)
+	}
+	sort.Strings(files)
+
+	want := []string{"package.json", "shared/composer.json", "web/package.json"}
+	if len(files) != len(want) {
+		t.Fatalf("walked files %q, want %q", files, want)
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Fatalf("walked files %q, want %q", files, want)
+		}
+	}
+}
+
+func TestWalkProjectFollowsSymlinksInsideRoot(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".src", "api", "package.json"))
+	symlink(t, filepath.Join(".src", "api"), filepath.Join(root, "api"))
+
+	var found []string
+	_ = walkProject(root, func(path string, d fs.DirEntry) error {
+		if d.IsDir() && d.Name() == ".src" {
+			return filepath.SkipDir
+		}
+		if d.Name() == "package.json" {
+			rel, _ := filepath.Rel(root, path)
+			found = append(found, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if len(found) != 1 || found[0] != "api/package.json" {
+		t.Errorf("walked %q, want the package.json behind the api symlink", found)
+	}
+}
+
+func TestWalkProjectSkipsScanIgnore(t *testing.T) {
+	defer func(saved []string) { detect.ScanIgnore = saved }(detect.ScanIgnore)
+	detect.ScanIgnore = []string{"node_modules", "build/*"}
+
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "package.json"))
+	writeFile(t, filepath.Join(root, "node_modules", "react", "package.json"))
+	writeFile(t, filepath.Join(root, "web", "node_modules", "vue", "package.json"))
+	writeFile(t, filepath.Join(root, "build", "out", "package.json"))
+	writeFile(t, filepath.Join(root, "build", "package.json"))
+
+	// Patterns match paths relative to the project root, which the scan is
+	// run from.
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	var files []string
+	err = walkProject(".", func(path string, d fs.DirEntry) error {
+		if !d.IsDir() {
+			files = append(files, filepath.ToSlash(path))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walkProject: %v", err)
+	}
+	sort.Strings(files)
+	if want := []string{"build/package.json", "package.json"}; len(files) != 2 || files[0] != want[0] || files[1] != want[1] {
+		t.Errorf("walked files %q, want %q", files, want)
+	}
+}
+
+func TestWalkProjectMaxDepth(t *testing.T) {
+	defer func(saved int) { MaxDepth = saved }(MaxDepth)
+
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "package.json"))
+	writeFile(t, filepath.Join(root, "services", "api", "go.mod"))
+
+	tests := []struct {
+		depth int
+		want  []string
+	}{
+		{depth: 1, want: []string{"package.json"}},
+		{depth: 2, want: []string{"package.json"}},
+		{depth: 3, want: []string{"package.json", "services/api/go.mod"}},
+		{depth: 0, want: []string{"package.json", "services/api/go.mod"}},
+	}
+	for _, tt := range tests {
+		MaxDepth = tt.depth
+		var files []string
+		err := walkProject(root, func(path string, d fs.DirEntry) error {
+			if !d.IsDir() {
+				rel, _ := filepath.Rel(root, path)
+				files = append(files, filepath.ToSlash(rel))
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("walkProject: %v", err)
+		}
+		sort.Strings(files)
+		if strings.Join(files, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("MaxDepth %d: walked files %q, want %q", tt.depth, files, tt.want)
+		}
+	}
+}
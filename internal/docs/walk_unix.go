@@ -0,0 +1,22 @@
+//go:build !windows
+
+package docs
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// dirKey identifies the directory at path by its device and inode, which are
+// the same through every symlink leading to it.
+func dirKey(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		return fmt.Sprintf("%d:%d", st.Dev, st.Ino), nil
+	}
+	return path, nil
+}
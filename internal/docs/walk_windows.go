@@ -0,0 +1,15 @@
+//go:build windows
+
+package docs
+
+import "path/filepath"
+
+// dirKey identifies the directory at path by its real path, which is the
+// same through every symlink or junction leading to it.
+func dirKey(path string) (string, error) {
+	real, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(real)
+}
@@ -0,0 +1,103 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+var (
+	defaultBranchOnce sync.Once
+	defaultBranch     string
+	defaultBranchErr  error
+)
+
+// DefaultBranch returns the default branch of the origin remote, e.g. "main",
+// as recorded by refs/remotes/origin/HEAD, falling back to asking the remote
+// with "git remote show origin". The result is cached for the rest of the
+// invocation.
+func DefaultBranch() (string, error) {
+	defaultBranchOnce.Do(func() {
+		defaultBranch, defaultBranchErr = resolveDefaultBranch()
+	})
+	return defaultBranch, defaultBranchErr
+}
+
+func resolveDefaultBranch() (string, error) {
+	if ref, err := gitOutput("symbolic-ref", "refs/remotes/origin/HEAD"); err == nil {
+		if branch := strings.TrimPrefix(ref, "refs/remotes/origin/"); branch != "" && branch != ref {
+			return branch, nil
+		}
+	}
+	out, err := gitOutput("remote", "show", "origin")
+	if err != nil {
+		return "", fmt.Errorf("could not determine the default branch: no origin remote HEAD is known")
+	}
+	if branch := parseHeadBranch(out); branch != "" {
+		return branch, nil
+	}
+	return "", fmt.Errorf("could not determine the default branch: origin does not report a HEAD branch")
+}
+
+// parseHeadBranch extracts the branch from the "HEAD branch: main" line of
+// "git remote show" output. It returns an empty string if the line is
+// missing or the branch is unknown, as it is for an empty remote.
+func parseHeadBranch(out string) string {
+	for _, line := range strings.Split(out, "\n") {
+		if branch, ok := strings.CutPrefix(strings.TrimSpace(line), "HEAD branch:"); ok {
+			branch = strings.TrimSpace(branch)
+			if branch == "(unknown)" {
+				return ""
+			}
+			return branch
+		}
+	}
+	return ""
+}
+
+// gitOutput runs git with the given arguments and returns its trimmed
+// standard output. Tests replace it to fake git's answers.
+var gitOutput = runGit
+
+// runGit is the real gitOutput. Messages are forced into English so they can
+// be parsed.
+func runGit(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// CurrentBranch returns the name of the checked-out branch, or the short
+// hash of the checked-out commit when HEAD is detached.
+func CurrentBranch() (string, error) {
+	name, _, err := Head()
+	return name, err
+}
+
+// Head returns what is checked out: the branch name, or the short commit hash
+// with detached set when HEAD is detached.
+func Head() (name string, detached bool, err error) {
+	branch, err := gitOutput("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		if _, err := gitOutput("rev-parse", "--git-dir"); err != nil {
+			return "", false, fmt.Errorf("not a git repository")
+		}
+		return "", false, fmt.Errorf("no commits yet")
+	}
+	if branch != "HEAD" {
+		return branch, false, nil
+	}
+	commit, err := gitOutput("rev-parse", "--short", "HEAD")
+	if err != nil {
+		return "", false, fmt.Errorf("resolving detached HEAD: %w", err)
+	}
+	return commit, true, nil
+}
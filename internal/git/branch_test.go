@@ -0,0 +1,125 @@
+package git
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeGit makes gitOutput answer from outputs, keyed by the space-separated
+// arguments, until the test ends. Commands missing from outputs fail.
+func fakeGit(t *testing.T, outputs map[string]string) {
+	t.Helper()
+	saved := gitOutput
+	t.Cleanup(func() { gitOutput = saved })
+	gitOutput = func(args ...string) (string, error) {
+		out, ok := outputs[strings.Join(args, " ")]
+		if !ok {
+			return "", errors.New("exit status 128")
+		}
+		return out, nil
+	}
+}
+
+func TestResolveDefaultBranch(t *testing.T) {
+	tests := []struct {
+		name    string
+		outputs map[string]string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "origin HEAD recorded",
+			outputs: map[string]string{"symbolic-ref refs/remotes/origin/HEAD": "refs/remotes/origin/main"},
+			want:    "main",
+		},
+		{
+			name: "asks the remote",
+			outputs: map[string]string{"remote show origin": `* remote origin
+  Fetch URL: git@github.com:adammpkins/OmniPath.git
+  HEAD branch: develop
+  Remote branches:`},
+			want: "develop",
+		},
+		{
+			name: "unexpected symbolic ref",
+			outputs: map[string]string{
+				"symbolic-ref refs/remotes/origin/HEAD": "refs/heads/main",
+				"remote show origin":                    "  HEAD branch: trunk",
+			},
+			want: "trunk",
+		},
+		{
+			name:    "empty remote",
+			outputs: map[string]string{"remote show origin": "  HEAD branch: (unknown)"},
+			wantErr: true,
+		},
+		{
+			name:    "no origin",
+			outputs: map[string]string{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGit(t, tt.outputs)
+			got, err := resolveDefaultBranch()
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("resolveDefaultBranch() = %q, %v; want %q, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHead(t *testing.T) {
+	tests := []struct {
+		name         string
+		outputs      map[string]string
+		want         string
+		wantDetached bool
+		wantErr      string
+	}{
+		{
+			name:    "on a branch",
+			outputs: map[string]string{"rev-parse --abbrev-ref HEAD": "feature/login"},
+			want:    "feature/login",
+		},
+		{
+			name: "detached",
+			outputs: map[string]string{
+				"rev-parse --abbrev-ref HEAD": "HEAD",
+				"rev-parse --short HEAD":      "e18a426",
+			},
+			want:         "e18a426",
+			wantDetached: true,
+		},
+		{
+			name:    "no commits",
+			outputs: map[string]string{"rev-parse --git-dir": ".git"},
+			wantErr: "no commits yet",
+		},
+		{
+			name:    "not a repository",
+			outputs: map[string]string{},
+			wantErr: "not a git repository",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGit(t, tt.outputs)
+			got, detached, err := Head()
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("Head() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want || detached != tt.wantDetached {
+				t.Errorf("Head() = %q, %v, %v; want %q, %v", got, detached, err, tt.want, tt.wantDetached)
+			}
+			if branch, _ := CurrentBranch(); branch != tt.want {
+				t.Errorf("CurrentBranch() = %q, want %q", branch, tt.want)
+			}
+		})
+	}
+}
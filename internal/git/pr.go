@@ -0,0 +1,44 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// NewPullRequestURL returns the page for opening a pull request (or GitLab
+// merge request) from branch into base, given the browser URL of the
+// repository as returned by ParseRemoteURL. GitHub, GitLab and Bitbucket are
+// recognised by their host names, so self-hosted instances need "github",
+// "gitlab" or "bitbucket" in theirs.
+func NewPullRequestURL(repoURL, base, branch string) (string, error) {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid repository URL %q: %w", repoURL, err)
+	}
+	repoURL = strings.TrimSuffix(repoURL, "/")
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case strings.Contains(host, "github"):
+		return fmt.Sprintf("%s/compare/%s...%s?expand=1", repoURL, escapeRef(base), escapeRef(branch)), nil
+	case strings.Contains(host, "gitlab"):
+		query := url.Values{"merge_request[source_branch]": {branch}}
+		if base != "" {
+			query.Set("merge_request[target_branch]", base)
+		}
+		return repoURL + "/-/merge_requests/new?" + query.Encode(), nil
+	case strings.Contains(host, "bitbucket"):
+		query := url.Values{"source": {branch}}
+		if base != "" {
+			query.Set("dest", base)
+		}
+		return repoURL + "/pull-requests/new?" + query.Encode(), nil
+	}
+	return "", fmt.Errorf("don't know how to open a pull request on %s", u.Host)
+}
+
+// escapeRef escapes a branch name for use in a URL path, keeping the slashes
+// of names like feature/login readable.
+func escapeRef(ref string) string {
+	return strings.ReplaceAll(url.PathEscape(ref), "%2F", "/")
+}
@@ -0,0 +1,55 @@
+package git
+
+import "testing"
+
+func TestNewPullRequestURLFromRemote(t *testing.T) {
+	tests := []struct {
+		remote, base, branch, want string
+	}{
+		{
+			"git@github.com:adammpkins/OmniPath.git", "main", "feature/login",
+			"https://github.com/adammpkins/OmniPath/compare/main...feature/login?expand=1",
+		},
+		{
+			"https://github.com/adammpkins/OmniPath.git", "main", "fix#12",
+			"https://github.com/adammpkins/OmniPath/compare/main...fix%2312?expand=1",
+		},
+		{
+			"git@gitlab.com:group/subgroup/app.git", "develop", "feature/login",
+			"https://gitlab.com/group/subgroup/app/-/merge_requests/new?merge_request%5Bsource_branch%5D=feature%2Flogin&merge_request%5Btarget_branch%5D=develop",
+		},
+		{
+			"https://gitlab.example.com/group/app", "main", "fix",
+			"https://gitlab.example.com/group/app/-/merge_requests/new?merge_request%5Bsource_branch%5D=fix&merge_request%5Btarget_branch%5D=main",
+		},
+		{
+			"git@bitbucket.org:team/app.git", "main", "feature/login",
+			"https://bitbucket.org/team/app/pull-requests/new?dest=main&source=feature%2Flogin",
+		},
+		{
+			"https://bitbucket.org/team/app.git", "main", "fix",
+			"https://bitbucket.org/team/app/pull-requests/new?dest=main&source=fix",
+		},
+	}
+	for _, tt := range tests {
+		repoURL, err := ParseRemoteURL(tt.remote)
+		if err != nil {
+			t.Errorf("ParseRemoteURL(%q): %v", tt.remote, err)
+			continue
+		}
+		got, err := NewPullRequestURL(repoURL, tt.base, tt.branch)
+		if err != nil {
+			t.Errorf("NewPullRequestURL for %q: %v", tt.remote, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("pull request URL for %q = %q, want %q", tt.remote, got, tt.want)
+		}
+	}
+}
+
+func TestNewPullRequestURLUnknownHost(t *testing.T) {
+	if _, err := NewPullRequestURL("https://git.example.com/team/app", "main", "fix"); err == nil {
+		t.Error("NewPullRequestURL succeeded for an unknown host")
+	}
+}
@@ -0,0 +1,209 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/adammpkins/OmniPath/internal/version"
+)
+
+const (
+	// DefaultTTL is how long a cached response is used without asking the
+	// server whether it changed.
+	DefaultTTL = time.Hour
+
+	// DefaultTimeout limits each request, including reading the body.
+	DefaultTimeout = 10 * time.Second
+
+	// maxBodySize caps how much of a response is read and cached.
+	maxBodySize = 10 << 20
+)
+
+// Options configures a Client. The zero value uses the defaults.
+type Options struct {
+	// Dir is where responses are cached. Empty means an omnipath/http
+	// directory under the user's cache directory.
+	Dir string
+
+	// TTL is how long a cached response is fresh. Zero uses DefaultTTL.
+	TTL time.Duration
+
+	// Timeout limits each request. Zero uses DefaultTimeout.
+	Timeout time.Duration
+}
+
+// Client fetches URLs over HTTP, caching responses on disk. Fresh responses
+// are served from the cache without a request; stale ones are revalidated
+// with their ETag or Last-Modified date, and are still returned if the
+// server can't be reached, so fetching works offline once a page was seen.
+// Requests identify OmniPath in their User-Agent and honour $HTTP_PROXY,
+// $HTTPS_PROXY and $NO_PROXY.
+type Client struct {
+	http *http.Client
+	dir  string
+	ttl  time.Duration
+}
+
+// New returns a Client configured by opts.
+func New(opts Options) (*Client, error) {
+	dir := opts.Dir
+	if dir == "" {
+		cache, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("locating cache directory: %w", err)
+		}
+		dir = filepath.Join(cache, "omnipath", "http")
+	}
+	ttl := opts.TTL
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	return &Client{
+		http: &http.Client{Transport: transport, Timeout: timeout},
+		dir:  dir,
+		ttl:  ttl,
+	}, nil
+}
+
+// entry is a cached response.
+type entry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Fetched      time.Time `json:"fetched"` // When the server last confirmed the body.
+	Body         []byte    `json:"body"`
+}
+
+// Get returns the body of url, from the cache if it is fresh enough.
+func (c *Client) Get(ctx context.Context, url string) ([]byte, error) {
+	cached, _ := c.load(url)
+	if cached != nil && time.Since(cached.Fetched) < c.ttl {
+		return cached.Body, nil
+	}
+
+	body, err := c.fetch(ctx, url, cached)
+	if err != nil {
+		// A stale copy beats no copy when offline or the server is down.
+		if cached != nil {
+			return cached.Body, nil
+		}
+		return nil, err
+	}
+	return body, nil
+}
+
+// fetch requests url, revalidating cached if it isn't nil, and updates the
+// cache with the result.
+func (c *Client) fetch(ctx context.Context, url string, cached *entry) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", UserAgent())
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		cached.Fetched = time.Now()
+		_ = c.store(cached)
+		return cached.Body, nil
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", url, err)
+	}
+	// Failing to cache only makes the next fetch slower.
+	_ = c.store(&entry{
+		URL:          url,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Fetched:      time.Now(),
+		Body:         body,
+	})
+	return body, nil
+}
+
+// path returns the cache file of url.
+func (c *Client) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// load reads the cached response for url, if there is one.
+func (c *Client) load(url string) (*entry, error) {
+	data, err := os.ReadFile(c.path(url))
+	if err != nil {
+		return nil, err
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	if e.URL != url {
+		return nil, fmt.Errorf("cache entry for %s holds %s", url, e.URL)
+	}
+	return &e, nil
+}
+
+// store writes e to the cache, replacing the file atomically so concurrent
+// readers never see a partial entry.
+func (c *Client) store(e *entry) error {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.dir, "*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(e.URL))
+}
+
+// UserAgent identifies OmniPath and its version to servers, e.g.
+// "OmniPath/v1.2.0 (+https://github.com/adammpkins/OmniPath)".
+func UserAgent() string {
+	return "OmniPath/" + version.Get().Version + " (+https://github.com/adammpkins/OmniPath)"
+}
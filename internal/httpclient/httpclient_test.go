@@ -0,0 +1,150 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newClient(t *testing.T, ttl time.Duration) *Client {
+	t.Helper()
+	c, err := New(Options{Dir: t.TempDir(), TTL: ttl})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func get(t *testing.T, c *Client, url string) string {
+	t.Helper()
+	body, err := c.Get(context.Background(), url)
+	if err != nil {
+		t.Fatalf("Get(%s): %v", url, err)
+	}
+	return string(body)
+}
+
+// age marks the cached response for url as fetched long ago, so it is stale.
+func age(t *testing.T, c *Client, url string) time.Time {
+	t.Helper()
+	e, err := c.load(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Fetched = time.Now().Add(-48 * time.Hour)
+	if err := c.store(e); err != nil {
+		t.Fatal(err)
+	}
+	return e.Fetched
+}
+
+func TestFreshCacheHitSendsNoRequest(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte("docs"))
+	}))
+	defer srv.Close()
+
+	c := newClient(t, time.Hour)
+	for i := 0; i < 3; i++ {
+		if got := get(t, c, srv.URL); got != "docs" {
+			t.Fatalf("Get = %q, want %q", got, "docs")
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("sent %d requests, want 1", n)
+	}
+}
+
+func TestRevalidatesWithETag(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("docs v1"))
+	}))
+	defer srv.Close()
+
+	c := newClient(t, time.Hour)
+	get(t, c, srv.URL)
+	old := age(t, c, srv.URL)
+
+	if got := get(t, c, srv.URL); got != "docs v1" {
+		t.Errorf("Get after 304 = %q, want the cached body", got)
+	}
+	e, err := c.load(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !e.Fetched.After(old) {
+		t.Errorf("Fetched = %v after a 304, want it refreshed", e.Fetched)
+	}
+	// Refreshed, the entry is fresh again.
+	get(t, c, srv.URL)
+	if n := requests.Load(); n != 2 {
+		t.Errorf("sent %d requests, want 2", n)
+	}
+}
+
+func TestRevalidatesWithLastModified(t *testing.T) {
+	const modified = "Wed, 21 Oct 2015 07:28:00 GMT"
+	var revalidated atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") == modified {
+			revalidated.Store(true)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", modified)
+		w.Write([]byte("docs"))
+	}))
+	defer srv.Close()
+
+	c := newClient(t, time.Hour)
+	get(t, c, srv.URL)
+	age(t, c, srv.URL)
+	if got := get(t, c, srv.URL); got != "docs" {
+		t.Errorf("Get after 304 = %q, want the cached body", got)
+	}
+	if !revalidated.Load() {
+		t.Error("stale response wasn't revalidated with If-Modified-Since")
+	}
+}
+
+func TestStaleBodyWhenServerUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("docs"))
+	}))
+	c := newClient(t, time.Hour)
+	get(t, c, srv.URL)
+	age(t, c, srv.URL)
+	srv.Close()
+
+	if got := get(t, c, srv.URL); got != "docs" {
+		t.Errorf("Get while offline = %q, want the stale body", got)
+	}
+	if _, err := c.Get(context.Background(), srv.URL+"/never-fetched"); err == nil {
+		t.Error("Get of an uncached URL while offline succeeded")
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	agent := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agent <- r.UserAgent()
+	}))
+	defer srv.Close()
+
+	get(t, newClient(t, time.Hour), srv.URL)
+	if got := <-agent; got != UserAgent() {
+		t.Errorf("User-Agent = %q, want %q", got, UserAgent())
+	}
+}
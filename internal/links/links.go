@@ -0,0 +1,60 @@
+package links
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File is where a project lists its named URLs, mapping each alias to a URL:
+//
+//	ci: https://github.com/acme/app/actions
+//	staging: https://staging.acme.dev
+var File = filepath.Join(".omnipath", "links.yaml")
+
+// Link is a named project URL.
+type Link struct {
+	Alias string
+	URL   string
+}
+
+// Load reads the project's links in the order they are declared. It returns
+// an error wrapping os.ErrNotExist when the file doesn't exist.
+func Load() ([]Link, error) {
+	data, err := os.ReadFile(File)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", File, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parsing %s: expected a mapping of aliases to URLs", File)
+	}
+	var links []Link
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if value.Kind != yaml.ScalarNode || value.Value == "" {
+			return nil, fmt.Errorf("parsing %s: link %q must be a URL", File, key.Value)
+		}
+		links = append(links, Link{Alias: key.Value, URL: value.Value})
+	}
+	return links, nil
+}
+
+// Find returns the link with the given alias.
+func Find(links []Link, alias string) (Link, bool) {
+	for _, l := range links {
+		if l.Alias == alias {
+			return l, true
+		}
+	}
+	return Link{}, false
+}
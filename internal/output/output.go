@@ -0,0 +1,110 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/adammpkins/OmniPath/internal/detect"
+	"github.com/adammpkins/OmniPath/internal/docs"
+)
+
+// SchemaVersion is the version of the JSON documents in this package. It is
+// bumped when a field is removed, renamed or changes meaning; adding a field
+// doesn't change it.
+const SchemaVersion = 1
+
+// Output formats accepted by the --output flag.
+const (
+	FormatText = "text" // For people; "table" is accepted too.
+	FormatJSON = "json" // A versioned JSON document, for tools.
+)
+
+// ParseFormat validates the value of an --output flag, returning FormatText
+// or FormatJSON.
+func ParseFormat(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "", FormatText, "table":
+		return FormatText, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	}
+	return "", fmt.Errorf("unknown output format %q: use text or json", format)
+}
+
+// Dependencies is the JSON document listing detected dependencies.
+type Dependencies struct {
+	SchemaVersion int                   `json:"schemaVersion"`
+	Dependencies  []docs.DependencyDocs `json:"dependencies"`
+}
+
+// Services is the JSON document listing the services "omnipath run" offers.
+type Services struct {
+	SchemaVersion int              `json:"schemaVersion"`
+	Services      []detect.Service `json:"services"`
+}
+
+// Doctor is the JSON document describing what OmniPath detects in a project,
+// as "omnipath doctor" shows it.
+type Doctor struct {
+	SchemaVersion int                     `json:"schemaVersion"`
+	Version       string                  `json:"version"` // OmniPath's version, e.g. "v1.2.0".
+	OS            string                  `json:"os"`
+	Arch          string                  `json:"arch"`
+	Detectors     []detect.DetectorResult `json:"detectors"`
+	Services      []detect.Service        `json:"services"`
+	Dependencies  []docs.DependencyDocs   `json:"dependencies"`
+	// DependencyError explains why dependency detection failed, if it did.
+	DependencyError string  `json:"dependencyError,omitempty"`
+	Git             Git     `json:"git"`
+	Browser         Browser `json:"browser"`
+}
+
+// Git describes the project's git remote and checkout.
+type Git struct {
+	Remote   string `json:"remote,omitempty"`   // The origin remote, as configured.
+	URL      string `json:"url,omitempty"`      // Its web URL.
+	Error    string `json:"error,omitempty"`    // Why either couldn't be determined.
+	Branch   string `json:"branch,omitempty"`   // The checked-out branch, or commit if detached.
+	Detached bool   `json:"detached,omitempty"` // Whether HEAD is detached.
+}
+
+// Browser describes how URLs are opened.
+type Browser struct {
+	Launcher string `json:"launcher,omitempty"` // The command used to open URLs.
+	Path     string `json:"path,omitempty"`     // Where it was found on PATH.
+	Error    string `json:"error,omitempty"`    // Why it can't be used.
+}
+
+// JSON writes v as indented JSON. v should be one of this package's
+// documents with SchemaVersion set.
+func JSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// DependencyHeader is the header row of a dependency table.
+var DependencyHeader = []string{"NAME", "CATEGORY", "URL", "SOURCE"}
+
+// DependencyRows returns the rows of a dependency table, in the columns of
+// DependencyHeader.
+func DependencyRows(deps []docs.DependencyDocs) [][]string {
+	rows := make([][]string, len(deps))
+	for i, d := range deps {
+		rows[i] = []string{d.Name, d.Category, d.DocURL, d.Source}
+	}
+	return rows
+}
+
+// Table writes rows as columns aligned with spaces, below a header row.
+func Table(w io.Writer, header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,120 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// explicitPortPatterns match ports spelled out in a command line. The first
+// capture group of each is the port number.
+var explicitPortPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?:localhost|127\.0\.0\.1|0\.0\.0\.0|\[::1?\]):(\d{2,5})\b`),
+	regexp.MustCompile(`--port[= ](\d{2,5})\b`),
+	regexp.MustCompile(`(?:^|\s)-p\s*(\d{2,5})\b`),
+	regexp.MustCompile(`\bPORT=(\d{2,5})\b`),
+}
+
+// defaultPorts are the ports well-known dev servers bind when the command
+// doesn't name one, keyed by a fragment of the command.
+var defaultPorts = []struct {
+	fragment string
+	port     int
+}{
+	{"artisan serve", 8000},
+	{"manage.py runserver", 8000},
+	{"flask run", 5000},
+	{"rails server", 3000},
+	{"rails s", 3000},
+	{"hugo server", 1313},
+	{"jekyll serve", 4000},
+	{"eleventy --serve", 8080},
+	{"storybook", 6006},
+	{"vite", 5173},
+	{"next dev", 3000},
+	{"spring-boot:run", 8080},
+	{"bootRun", 8080},
+}
+
+// Extract returns the TCP ports a service command is likely to listen on, in
+// ascending order. Ports written in the command win; otherwise the default
+// port of a recognised dev server is used.
+func Extract(command string) []int {
+	seen := make(map[int]bool)
+	for _, pattern := range explicitPortPatterns {
+		for _, match := range pattern.FindAllStringSubmatch(command, -1) {
+			if port, err := strconv.Atoi(match[1]); err == nil && port > 0 && port <= 65535 {
+				seen[port] = true
+			}
+		}
+	}
+	if len(seen) == 0 {
+		for _, d := range defaultPorts {
+			if strings.Contains(command, d.fragment) {
+				seen[d.port] = true
+				break
+			}
+		}
+	}
+
+	ports := make([]int, 0, len(seen))
+	for port := range seen {
+		ports = append(ports, port)
+	}
+	sort.Ints(ports)
+	return ports
+}
+
+// InUse reports whether something is already listening on the TCP port, by
+// briefly trying to bind it on all interfaces and on loopback.
+func InUse(port int) bool {
+	for _, host := range []string{"", "127.0.0.1"} {
+		l, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+		if err != nil {
+			if errors.Is(err, errAddrInUse) {
+				return true
+			}
+			continue
+		}
+		l.Close()
+	}
+	return false
+}
+
+// Backoff bounds for WaitListening's polling.
+const (
+	minPollInterval = 100 * time.Millisecond
+	maxPollInterval = 2 * time.Second
+)
+
+// Listening reports whether something accepts TCP connections on the port on
+// localhost.
+func Listening(port int) bool {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort("localhost", strconv.Itoa(port)), time.Second)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// WaitListening polls the port until something listens on it, backing off
+// exponentially between attempts. It returns ctx's error if ctx is done
+// first.
+func WaitListening(ctx context.Context, port int) error {
+	interval := minPollInterval
+	for !Listening(port) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+		interval = min(interval*2, maxPollInterval)
+	}
+	return nil
+}
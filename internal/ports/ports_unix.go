@@ -0,0 +1,8 @@
+//go:build !windows
+
+package ports
+
+import "syscall"
+
+// errAddrInUse is the error a listener fails with when the port is taken.
+var errAddrInUse = syscall.EADDRINUSE
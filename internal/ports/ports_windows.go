@@ -0,0 +1,9 @@
+//go:build windows
+
+package ports
+
+import "golang.org/x/sys/windows"
+
+// errAddrInUse is the error a listener fails with when the port is taken.
+// Winsock reports its own code rather than syscall.EADDRINUSE.
+var errAddrInUse = windows.WSAEADDRINUSE
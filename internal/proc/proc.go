@@ -0,0 +1,122 @@
+package proc
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// DefaultGracePeriod is how long Cleanup waits after SIGTERM before killing
+// the remaining processes.
+const DefaultGracePeriod = 5 * time.Second
+
+// stopSignal is a step in stopping a process group, in increasing severity.
+// Each platform maps them onto its own mechanism in signalGroup.
+type stopSignal int
+
+const (
+	sigInterrupt stopSignal = iota // SIGINT, like pressing Ctrl+C.
+	sigTerminate                   // SIGTERM, asking the processes to exit.
+	sigKill                        // SIGKILL, which can't be ignored.
+)
+
+var (
+	mu     sync.Mutex
+	groups = make(map[*exec.Cmd]int) // Tracked commands and their process group IDs.
+)
+
+// Command returns a command that runs line with the platform's shell, sh or
+// cmd.exe, in a new process group of its own.
+func Command(line string) *exec.Cmd {
+	return CommandContext(context.Background(), line)
+}
+
+// CommandContext is like Command, but kills the command's whole process
+// group if ctx is done before the command exits.
+func CommandContext(ctx context.Context, line string) *exec.Cmd {
+	c := command(ctx, line)
+	c.Cancel = func() error {
+		return signalGroup(c.Process.Pid, sigKill)
+	}
+	return c
+}
+
+// Track registers a started command whose process group should be stopped by
+// Cleanup. The command must have been created by Command so it leads its own
+// process group, whose ID equals its PID.
+func Track(c *exec.Cmd) {
+	if c.Process == nil {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	groups[c] = c.Process.Pid
+}
+
+// Release is called once a tracked command has been waited for. The command
+// stays tracked while other processes in its group are still running, since
+// they would otherwise be orphaned.
+func Release(c *exec.Cmd) {
+	mu.Lock()
+	defer mu.Unlock()
+	if pgid, ok := groups[c]; ok && !groupAlive(pgid) {
+		delete(groups, c)
+	}
+}
+
+// Cleanup sends SIGTERM to every tracked process group, waits up to grace for
+// them to exit, and then sends SIGKILL to any that remain. It is safe to call
+// more than once; each group is only cleaned up by the first call.
+func Cleanup(grace time.Duration) {
+	mu.Lock()
+	var pgids []int
+	for c, pgid := range groups {
+		pgids = append(pgids, pgid)
+		delete(groups, c)
+	}
+	mu.Unlock()
+
+	alive := signalAndWait(pgids, sigTerminate, grace)
+	Kill(alive)
+}
+
+// Stop shuts down the given process groups, escalating from SIGINT to
+// SIGTERM to SIGKILL. It waits up to grace after each of the first two
+// signals and returns once every group has exited or been killed.
+func Stop(pgids []int, grace time.Duration) {
+	alive := signalAndWait(pgids, sigInterrupt, grace)
+	alive = signalAndWait(alive, sigTerminate, grace)
+	Kill(alive)
+}
+
+// Kill sends SIGKILL to the given process groups.
+func Kill(pgids []int) {
+	for _, pgid := range pgids {
+		_ = signalGroup(pgid, sigKill)
+	}
+}
+
+// signalAndWait sends sig to each process group and waits up to grace for
+// them to exit. It returns the groups that are still running.
+func signalAndWait(pgids []int, sig stopSignal, grace time.Duration) []int {
+	var alive []int
+	for _, pgid := range pgids {
+		if signalGroup(pgid, sig) == nil {
+			alive = append(alive, pgid)
+		}
+	}
+
+	deadline := time.Now().Add(grace)
+	for len(alive) > 0 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+		remaining := alive[:0]
+		for _, pgid := range alive {
+			if groupAlive(pgid) {
+				remaining = append(remaining, pgid)
+			}
+		}
+		alive = remaining
+	}
+	return alive
+}
@@ -0,0 +1,40 @@
+//go:build !windows
+
+package proc
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// command returns a command that runs line with sh in a new process group,
+// so the whole group can be stopped together.
+func command(ctx context.Context, line string) *exec.Cmd {
+	c := exec.CommandContext(ctx, "sh", "-c", line)
+	c.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	return c
+}
+
+// CommandLine describes how Command runs line, for display.
+func CommandLine(line string) string {
+	return "sh -c '" + strings.ReplaceAll(line, "'", `'\''`) + "'"
+}
+
+var unixSignals = map[stopSignal]syscall.Signal{
+	sigInterrupt: syscall.SIGINT,
+	sigTerminate: syscall.SIGTERM,
+	sigKill:      syscall.SIGKILL,
+}
+
+// signalGroup sends sig to every process in the group.
+func signalGroup(pgid int, sig stopSignal) error {
+	return syscall.Kill(-pgid, unixSignals[sig])
+}
+
+// groupAlive reports whether any process remains in the process group.
+func groupAlive(pgid int) bool {
+	err := syscall.Kill(-pgid, 0)
+	return err == nil || err == syscall.EPERM
+}
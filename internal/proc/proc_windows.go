@@ -0,0 +1,65 @@
+//go:build windows
+
+package proc
+
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// command returns a command that runs line with cmd.exe in a new process
+// group, so the whole group can be stopped together. The command line is
+// passed through verbatim, since cmd.exe doesn't follow the quoting rules
+// exec.Command applies to arguments.
+func command(ctx context.Context, line string) *exec.Cmd {
+	c := exec.CommandContext(ctx, "cmd")
+	c.SysProcAttr = &syscall.SysProcAttr{
+		CmdLine:       CommandLine(line),
+		CreationFlags: windows.CREATE_NEW_PROCESS_GROUP,
+	}
+	return c
+}
+
+// CommandLine describes how Command runs line, for display.
+func CommandLine(line string) string {
+	return `cmd /s /c "` + line + `"`
+}
+
+// signalGroup stops the process group led by pgid. Windows has no signals:
+// an interrupt is a Ctrl+Break console event, which a new process group
+// receives in place of Ctrl+C, and terminating goes through taskkill, whose
+// /T also reaches child processes.
+func signalGroup(pgid int, sig stopSignal) error {
+	switch sig {
+	case sigInterrupt:
+		return windows.GenerateConsoleCtrlEvent(windows.CTRL_BREAK_EVENT, uint32(pgid))
+	case sigTerminate:
+		return exec.Command("taskkill", "/T", "/PID", strconv.Itoa(pgid)).Run()
+	default:
+		return exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(pgid)).Run()
+	}
+}
+
+// groupAlive reports whether the process leading the group is still running.
+// Windows doesn't track groups the way Unix does, so orphaned children that
+// outlive the leader aren't seen.
+func groupAlive(pgid int) bool {
+	h, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pgid))
+	if err != nil {
+		return false
+	}
+	defer windows.CloseHandle(h)
+	var code uint32
+	if err := windows.GetExitCodeProcess(h, &code); err != nil {
+		return false
+	}
+	return code == stillActive
+}
+
+// stillActive is the exit code GetExitCodeProcess reports for a process that
+// hasn't exited (STILL_ACTIVE).
+const stillActive = 259
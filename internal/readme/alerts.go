@@ -0,0 +1,129 @@
+package readme
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// alertClasses maps the type of each GitHub alert, as in "> [!NOTE]", to the
+// template's CSS class that styles it.
+var alertClasses = map[string]string{
+	"NOTE":      "note",
+	"TIP":       "tip",
+	"IMPORTANT": "important",
+	"WARNING":   "warning",
+	"CAUTION":   "danger",
+	"DANGER":    "danger",
+}
+
+// kindAlert is the node kind of alerts.
+var kindAlert = ast.NewNodeKind("Alert")
+
+// alertNode is a blockquote that turned out to be a GitHub alert.
+type alertNode struct {
+	ast.BaseBlock
+	class string // CSS class, one of alertClasses.
+	title string // Heading shown above the content, e.g. "Note".
+}
+
+func (n *alertNode) Kind() ast.NodeKind {
+	return kindAlert
+}
+
+func (n *alertNode) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Class": n.class}, nil)
+}
+
+// alerts is a goldmark extension rendering GitHub alerts, blockquotes whose
+// first line is [!NOTE], [!TIP], [!IMPORTANT], [!WARNING] or [!CAUTION], as
+// styled callouts. Other blockquotes are left alone.
+type alerts struct{}
+
+func (alerts) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithASTTransformers(util.Prioritized(alertTransformer{}, 100)))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(alertRenderer{}, 100)))
+}
+
+// alertTransformer replaces blockquotes that are alerts with alert nodes.
+type alertTransformer struct{}
+
+func (alertTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	// Collect the blockquotes first, since replacing nodes while walking
+	// the tree would derail the walk.
+	var quotes []*ast.Blockquote
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if q, ok := n.(*ast.Blockquote); ok && entering {
+			quotes = append(quotes, q)
+		}
+		return ast.WalkContinue, nil
+	})
+	for _, q := range quotes {
+		convertAlert(q, reader.Source())
+	}
+}
+
+// convertAlert replaces q with an alert node if its first line is an alert
+// marker, dropping the marker itself.
+func convertAlert(q *ast.Blockquote, source []byte) {
+	para, ok := q.FirstChild().(*ast.Paragraph)
+	if !ok || para.Lines().Len() == 0 {
+		return
+	}
+	first := para.Lines().At(0)
+	marker := strings.TrimSpace(string(first.Value(source)))
+	if !strings.HasPrefix(marker, "[!") || !strings.HasSuffix(marker, "]") {
+		return
+	}
+	kind := strings.ToUpper(marker[2 : len(marker)-1])
+	class, ok := alertClasses[kind]
+	if !ok {
+		return
+	}
+
+	// The marker may have been split into several text nodes, all of which
+	// start on the first line.
+	for c := para.FirstChild(); c != nil; {
+		t, ok := c.(*ast.Text)
+		if !ok || t.Segment.Start >= first.Stop {
+			break
+		}
+		next := c.NextSibling()
+		para.RemoveChild(para, c)
+		c = next
+	}
+	if para.ChildCount() == 0 {
+		q.RemoveChild(q, para)
+	}
+
+	alert := &alertNode{class: class, title: kind[:1] + strings.ToLower(kind[1:])}
+	for c := q.FirstChild(); c != nil; {
+		next := c.NextSibling()
+		alert.AppendChild(alert, c)
+		c = next
+	}
+	q.Parent().ReplaceChild(q.Parent(), q, alert)
+}
+
+// alertRenderer renders alert nodes as divs with the alert's class.
+type alertRenderer struct{}
+
+func (r alertRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindAlert, r.render)
+}
+
+func (r alertRenderer) render(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+	alert := n.(*alertNode)
+	if entering {
+		fmt.Fprintf(w, "<div class=\"%s\">\n<p class=\"alert-title\">%s</p>\n", alert.class, alert.title)
+	} else {
+		_, _ = w.WriteString("</div>\n")
+	}
+	return ast.WalkContinue, nil
+}
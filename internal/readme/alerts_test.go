@@ -0,0 +1,57 @@
+package readme
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark"
+)
+
+func convert(t *testing.T, md goldmark.Markdown, source string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(source), &buf); err != nil {
+		t.Fatalf("converting %q: %v", source, err)
+	}
+	return buf.String()
+}
+
+func TestAlerts(t *testing.T) {
+	md := goldmark.New(goldmark.WithExtensions(alerts{}))
+	tests := []struct{ marker, class, title string }{
+		{"[!NOTE]", "note", "Note"},
+		{"[!TIP]", "tip", "Tip"},
+		{"[!IMPORTANT]", "important", "Important"},
+		{"[!WARNING]", "warning", "Warning"},
+		{"[!CAUTION]", "danger", "Caution"},
+		{"[!note]", "note", "Note"},
+	}
+	for _, tt := range tests {
+		got := convert(t, md, "> "+tt.marker+"\n> Back up your *database* first.\n")
+		want := "<div class=\"" + tt.class + "\">\n<p class=\"alert-title\">" + tt.title + "</p>\n" +
+			"<p>Back up your <em>database</em> first.</p>\n</div>\n"
+		if got != want {
+			t.Errorf("%s rendered as\n%s\nwant\n%s", tt.marker, got, want)
+		}
+	}
+}
+
+func TestAlertsLeavePlainBlockquotes(t *testing.T) {
+	plain := goldmark.New()
+	md := goldmark.New(goldmark.WithExtensions(alerts{}))
+	for _, source := range []string{
+		"> Just a quote.\n",
+		"> [!UNKNOWN]\n> Not an alert type.\n",
+		"> See [!NOTE] in the middle.\n",
+		"> # Heading\n> [!NOTE]\n",
+	} {
+		got, want := convert(t, md, source), convert(t, plain, source)
+		if got != want {
+			t.Errorf("%q rendered as\n%s\nwant it unchanged:\n%s", source, got, want)
+		}
+		if !strings.Contains(got, "<blockquote>") {
+			t.Errorf("%q isn't a blockquote:\n%s", source, got)
+		}
+	}
+}
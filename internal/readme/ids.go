@@ -0,0 +1,51 @@
+package readme
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// githubIDs generates heading IDs the way GitHub does, so links written
+// against a README on GitHub, such as [Setup](#setup-macos), work in the
+// rendered page too. It implements goldmark's parser.IDs.
+type githubIDs struct {
+	used map[string]bool
+}
+
+func newGitHubIDs() *githubIDs {
+	return &githubIDs{used: make(map[string]bool)}
+}
+
+// Generate slugs a heading's text: it is lowercased, everything but letters,
+// digits, spaces, hyphens and underscores is dropped, and each space becomes
+// a hyphen. Repeated headings get -1, -2 and so on appended.
+func (ids *githubIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(string(value))) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	base := b.String()
+	if base == "" {
+		base = "heading"
+	}
+
+	id := base
+	for i := 1; ids.used[id]; i++ {
+		id = base + "-" + strconv.Itoa(i)
+	}
+	ids.used[id] = true
+	return []byte(id)
+}
+
+// Put records an ID set explicitly in the document, so generated IDs avoid it.
+func (ids *githubIDs) Put(value []byte) {
+	ids.used[string(value)] = true
+}
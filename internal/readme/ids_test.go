@@ -0,0 +1,80 @@
+package readme
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+func TestGitHubIDs(t *testing.T) {
+	ids := newGitHubIDs()
+	ids.Put([]byte("install"))
+	tests := []struct{ heading, want string }{
+		{"Setup (macOS)", "setup-macos"},
+		{"Usage", "usage"},
+		{"Usage", "usage-1"},
+		{"Usage", "usage-2"},
+		{"What's new in v2.0?", "whats-new-in-v20"},
+		{"snake_case and kebab-case", "snake_case-and-kebab-case"},
+		{"Install", "install-1"},
+		{"!!!", "heading"},
+	}
+	for _, tt := range tests {
+		if got := string(ids.Generate([]byte(tt.heading), ast.KindHeading)); got != tt.want {
+			t.Errorf("ID of %q = %q, want %q", tt.heading, got, tt.want)
+		}
+	}
+}
+
+// TestTableOfContentsLinks renders a README whose table of contents was
+// written against GitHub and checks each link finds its heading.
+func TestTableOfContentsLinks(t *testing.T) {
+	readme := `# Project
+
+- [Setup (macOS)](#setup-macos)
+- [Usage](#usage)
+- [Usage, again](#usage-1)
+
+## Setup (macOS)
+
+## Usage
+
+## Usage
+`
+	path := filepath.Join(t.TempDir(), "README.md")
+	if err := os.WriteFile(path, []byte(readme), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	page, err := RenderReadme(path, Options{NoEmoji: true})
+	if err != nil {
+		t.Fatalf("RenderReadme: %v", err)
+	}
+
+	ids := make(map[string]bool)
+	for _, m := range regexp.MustCompile(`<h[1-6] id="([^"]+)"`).FindAllStringSubmatch(string(page), -1) {
+		ids[m[1]] = true
+	}
+	links := regexp.MustCompile(`<a href="#([^"]+)"`).FindAllStringSubmatch(string(page), -1)
+	if len(links) != 3 {
+		t.Fatalf("found %d table of contents links, want 3", len(links))
+	}
+	for _, m := range links {
+		if !ids[m[1]] {
+			t.Errorf("link to #%s has no heading; heading IDs are %s", m[1], strings.Join(keys(ids), ", "))
+		}
+	}
+}
+
+func keys(m map[string]bool) []string {
+	var s []string
+	for k := range m {
+		s = append(s, k)
+	}
+	sort.Strings(s)
+	return s
+}
@@ -2,13 +2,17 @@ package readme
 
 import (
 	"bytes"
+	"embed"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"text/template"
 
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
 	"github.com/yuin/goldmark"
+	emoji "github.com/yuin/goldmark-emoji"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/renderer/html"
@@ -21,8 +25,8 @@ const htmlTemplate = `<!DOCTYPE html>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
     <title>README</title>
-    <link rel="stylesheet" href="https://cdnjs.cloudflare.com/ajax/libs/font-awesome/6.0.0/css/all.min.css">
-    <link rel="stylesheet" href="https://cdnjs.cloudflare.com/ajax/libs/highlight.js/11.7.0/styles/atom-one-dark.min.css">
+    {{if not .Offline}}<link rel="stylesheet" href="https://cdnjs.cloudflare.com/ajax/libs/font-awesome/6.0.0/css/all.min.css">
+    <link rel="stylesheet" href="https://cdnjs.cloudflare.com/ajax/libs/highlight.js/11.7.0/styles/atom-one-dark.min.css">{{end}}
     <style>
         :root {
             --bg-primary: #0d1117;
@@ -37,6 +41,7 @@ const htmlTemplate = `<!DOCTYPE html>
             --success-color: #3fb950;
             --warning-color: #d29922;
             --error-color: #f85149;
+            --important-color: #a371f7;
             --font-sans: -apple-system, BlinkMacSystemFont, 'Segoe UI', Helvetica, Arial, sans-serif, 'Apple Color Emoji', 'Segoe UI Emoji';
             --font-mono: SFMono-Regular, Consolas, 'Liberation Mono', Menlo, monospace;
             --max-width: 960px;
@@ -107,15 +112,50 @@ const htmlTemplate = `<!DOCTYPE html>
         h5 { font-size: 0.875rem; }
         h6 { font-size: 0.85rem; }
 
-        /* Link anchor tags for headings */
-        h1:hover::before, h2:hover::before, h3:hover::before, 
-        h4:hover::before, h5:hover::before, h6:hover::before {
-            content: "#";
+        /* Link anchors for headings, added by the script below */
+        .anchor {
             position: absolute;
             left: -1.25rem;
             color: var(--accent-color);
             font-weight: normal;
-            opacity: 0.6;
+            text-decoration: none;
+            opacity: 0.25;
+            transition: opacity 0.2s;
+        }
+
+        h1:hover .anchor, h2:hover .anchor, h3:hover .anchor,
+        h4:hover .anchor, h5:hover .anchor, h6:hover .anchor,
+        .anchor:focus {
+            opacity: 1;
+        }
+
+        /* Back to top button, shown once the page is scrolled */
+        #back-to-top {
+            position: fixed;
+            right: 1.5rem;
+            bottom: 1.5rem;
+            width: 2.5rem;
+            height: 2.5rem;
+            line-height: 2.5rem;
+            text-align: center;
+            border-radius: 50%;
+            background-color: var(--bg-tertiary);
+            border: 1px solid var(--border-color);
+            color: var(--text-primary);
+            text-decoration: none;
+            box-shadow: 0 2px 8px rgba(0, 0, 0, 0.3);
+            opacity: 0;
+            visibility: hidden;
+            transition: opacity 0.2s, visibility 0.2s;
+        }
+
+        #back-to-top.visible {
+            opacity: 1;
+            visibility: visible;
+        }
+
+        #back-to-top:hover {
+            color: var(--accent-color);
         }
 
         /* Text elements */
@@ -277,7 +317,7 @@ const htmlTemplate = `<!DOCTYPE html>
         }
 
         /* Custom container classes */
-        .note, .tip, .warning, .danger {
+        .note, .tip, .important, .warning, .danger {
             margin: 1rem 0;
             padding: 1rem;
             border-radius: var(--radius-md);
@@ -294,6 +334,11 @@ const htmlTemplate = `<!DOCTYPE html>
             border-left-color: var(--success-color);
         }
 
+        .important {
+            background-color: rgba(163, 113, 247, 0.1);
+            border-left-color: var(--important-color);
+        }
+
         .warning {
             background-color: rgba(210, 153, 34, 0.1);
             border-left-color: var(--warning-color);
@@ -304,6 +349,16 @@ const htmlTemplate = `<!DOCTYPE html>
             border-left-color: var(--error-color);
         }
 
+        .alert-title {
+            font-weight: 600;
+            margin-top: 0;
+        }
+
+        .note > :last-child, .tip > :last-child, .important > :last-child,
+        .warning > :last-child, .danger > :last-child {
+            margin-bottom: 0;
+        }
+
         /* Footer */
         .footer {
             margin-top: 2rem;
@@ -333,6 +388,70 @@ const htmlTemplate = `<!DOCTYPE html>
             }
         }
     </style>
+    {{if .Offline}}<style>
+{{.IconCSS}}
+{{.HighlightCSS}}
+    </style>{{end}}
+    <style>
+        /* Printing: a light theme that saves ink, without decorations, that
+           keeps code blocks and tables on one page where possible. */
+        @media {{if .PrintCSS}}all{{else}}print{{end}} {
+            :root {
+                --bg-primary: #ffffff;
+                --bg-secondary: #ffffff;
+                --bg-tertiary: #f6f8fa;
+                --text-primary: #000000;
+                --text-secondary: #000000;
+                --text-muted: #57606a;
+                --border-color: #d0d7de;
+                --accent-color: #0550ae;
+                --accent-hover: #0550ae;
+            }
+
+            #container {
+                max-width: none;
+                padding: 0;
+            }
+
+            #content {
+                border: none;
+                box-shadow: none;
+                padding: 0;
+            }
+
+            pre::before, pre::after {
+                content: none;
+                display: none;
+            }
+
+            .anchor, #back-to-top {
+                display: none;
+            }
+
+            pre code {
+                top: 0;
+            }
+
+            pre, pre code, pre code span, .hljs, .chroma {
+                background: var(--bg-tertiary) !important;
+                color: #000000 !important;
+            }
+
+            pre, table, img, blockquote {
+                page-break-inside: avoid;
+                break-inside: avoid;
+            }
+
+            h1, h2, h3, h4, h5, h6 {
+                page-break-after: avoid;
+                break-after: avoid;
+            }
+
+            .footer {
+                display: none;
+            }
+        }
+    </style>
 </head>
 <body>
     <div id="container">
@@ -343,26 +462,35 @@ const htmlTemplate = `<!DOCTYPE html>
             <p>Generated with <i class="fas fa-heart"></i> using Go README Renderer</p>
         </div>
     </div>
+    <a id="back-to-top" href="#" aria-label="Back to top">&#8593;</a>
 
-    <script src="https://cdnjs.cloudflare.com/ajax/libs/highlight.js/11.7.0/highlight.min.js"></script>
+    {{if not .Offline}}<script src="https://cdnjs.cloudflare.com/ajax/libs/highlight.js/11.7.0/highlight.min.js"></script>{{end}}
     <script>
-        // Apply code highlighting
         document.addEventListener('DOMContentLoaded', (event) => {
-            document.querySelectorAll('pre code').forEach((block) => {
-                hljs.highlightElement(block);
-            });
+            // Apply code highlighting, unless it was done when rendering
+            if (window.hljs) {
+                document.querySelectorAll('pre code').forEach((block) => {
+                    hljs.highlightElement(block);
+                });
+            }
+
+            // Give each heading a visible anchor link. The IDs are the ones
+            // assigned when rendering, so copied links match the page.
+            document.querySelectorAll('#content h1[id], #content h2[id], #content h3[id], #content h4[id], #content h5[id], #content h6[id]').forEach((heading) => {
+                const anchor = document.createElement('a');
+                anchor.className = 'anchor';
+                anchor.href = '#' + heading.id;
+                anchor.textContent = '#';
+                anchor.setAttribute('aria-label', 'Copy link to this section');
+                heading.prepend(anchor);
+
+                // Following the link also copies its URL
+                anchor.addEventListener('click', () => {
+                    const url = window.location.href.split('#')[0] + '#' + heading.id;
+                    if (navigator.clipboard) {
+                        navigator.clipboard.writeText(url);
+                    }
 
-            // Convert h1-h6 to have anchor links
-            document.querySelectorAll('h1, h2, h3, h4, h5, h6').forEach((heading) => {
-                // Create anchor id from heading text
-                const id = heading.textContent.toLowerCase().replace(/[^\w]+/g, '-');
-                heading.setAttribute('id', id);
-                
-                // Make headings clickable to copy URL
-                heading.addEventListener('click', () => {
-                    const url = window.location.href.split('#')[0] + '#' + id;
-                    navigator.clipboard.writeText(url);
-                    
                     // Visual feedback
                     const originalColor = heading.style.color;
                     heading.style.color = 'var(--accent-color)';
@@ -371,21 +499,73 @@ const htmlTemplate = `<!DOCTYPE html>
                     }, 300);
                 });
             });
+
+            // Show the back to top button once the first screen is scrolled past
+            const backToTop = document.getElementById('back-to-top');
+            const updateBackToTop = () => {
+                backToTop.classList.toggle('visible', window.scrollY > window.innerHeight / 2);
+            };
+            window.addEventListener('scroll', updateBackToTop, { passive: true });
+            updateBackToTop();
+            backToTop.addEventListener('click', (e) => {
+                e.preventDefault();
+                window.scrollTo({ top: 0, behavior: 'smooth' });
+                history.replaceState(null, '', window.location.pathname + window.location.search);
+            });
         });
     </script>
 </body>
 </html>`
 
-// ServeReadmeAsHTML reads README.md from the project root, converts it to HTML, and serves it with modern dark styling.
-func ServeReadmeAsHTML(readmePath, port string) {
+// highlightStyle is the chroma style used for offline highlighting, matching
+// the atom-one-dark theme loaded from the CDN otherwise.
+const highlightStyle = "onedark"
+
+// assets holds the vendored stylesheets inlined into offline pages in place
+// of the ones loaded from CDNs.
+//
+//go:embed assets
+var assets embed.FS
+
+// Options configures how the README is rendered.
+type Options struct {
+	// Offline makes the page self-contained: code is highlighted while
+	// rendering and the stylesheets are inlined, instead of loading
+	// highlight.js and Font Awesome from CDNs in the browser.
+	Offline bool
+
+	// NoEmoji leaves shortcodes such as :rocket: as text instead of
+	// rendering them as emoji the way GitHub does.
+	NoEmoji bool
+
+	// PrintCSS shows the page with its print stylesheet on screen too, to
+	// preview what printing or saving it as a PDF produces.
+	PrintCSS bool
+}
+
+// RenderReadme reads the Markdown file at readmePath and returns it rendered
+// as a complete HTML page with modern dark styling.
+func RenderReadme(readmePath string, opts Options) ([]byte, error) {
 	content, err := ioutil.ReadFile(readmePath)
 	if err != nil {
-		log.Fatalf("Error reading %s: %v", readmePath, err)
+		return nil, fmt.Errorf("reading %s: %w", readmePath, err)
 	}
 
 	// Configure goldmark with GitHub Flavored Markdown extensions
+	extensions := []goldmark.Extender{extension.GFM, alerts{}}
+	if !opts.NoEmoji {
+		extensions = append(extensions, emoji.Emoji)
+	}
+	var highlightCSS bytes.Buffer
+	if opts.Offline {
+		extensions = append(extensions, highlighting.NewHighlighting(
+			highlighting.WithStyle(highlightStyle),
+			highlighting.WithCSSWriter(&highlightCSS),
+			highlighting.WithFormatOptions(chromahtml.WithClasses(true)),
+		))
+	}
 	md := goldmark.New(
-		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithExtensions(extensions...),
 		goldmark.WithParserOptions(
 			parser.WithAutoHeadingID(),
 		),
@@ -398,28 +578,50 @@ func ServeReadmeAsHTML(readmePath, port string) {
 
 	// Convert Markdown to HTML
 	var buf bytes.Buffer
-	if err := md.Convert(content, &buf); err != nil {
-		log.Fatalf("Error converting Markdown to HTML: %v", err)
+	// Heading IDs follow GitHub's rules, and the page's script uses them as
+	// they are rather than computing its own.
+	ctx := parser.NewContext(parser.WithIDs(newGitHubIDs()))
+	if err := md.Convert(content, &buf, parser.WithContext(ctx)); err != nil {
+		return nil, fmt.Errorf("converting Markdown to HTML: %w", err)
+	}
+
+	iconCSS, err := assets.ReadFile("assets/icons.css")
+	if err != nil {
+		return nil, err
 	}
 
 	// Prepare the full HTML by wrapping the converted content with our template
 	tmpl, err := template.New("readme").Parse(htmlTemplate)
 	if err != nil {
-		log.Fatalf("Error parsing HTML template: %v", err)
+		return nil, fmt.Errorf("parsing HTML template: %w", err)
 	}
 
 	var fullHTML bytes.Buffer
 	err = tmpl.Execute(&fullHTML, map[string]interface{}{
-		"Content": buf.String(),
+		"Content":      buf.String(),
+		"Offline":      opts.Offline,
+		"PrintCSS":     opts.PrintCSS,
+		"IconCSS":      string(iconCSS),
+		"HighlightCSS": highlightCSS.String(),
 	})
 	if err != nil {
-		log.Fatalf("Error executing HTML template: %v", err)
+		return nil, fmt.Errorf("executing HTML template: %w", err)
+	}
+	return fullHTML.Bytes(), nil
+}
+
+// ServeReadmeAsHTML reads README.md from the project root, converts it to HTML, and serves it with modern dark styling.
+// It only returns if the README can't be rendered or the server fails.
+func ServeReadmeAsHTML(readmePath, port string, opts Options) error {
+	page, err := RenderReadme(readmePath, opts)
+	if err != nil {
+		return err
 	}
 
 	// Set up the HTTP server
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/html")
-		w.Write(fullHTML.Bytes())
+		w.Write(page)
 	})
 
 	// Set up static file serving for potential assets
@@ -428,5 +630,5 @@ func ServeReadmeAsHTML(readmePath, port string) {
 
 	addr := fmt.Sprintf(":%s", port)
 	log.Printf("✨ Serving %s as HTML on http://localhost:%s", readmePath, port)
-	log.Fatal(http.ListenAndServe(addr, nil))
+	return http.ListenAndServe(addr, nil)
 }
@@ -0,0 +1,68 @@
+package readme
+
+import (
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// render writes readme to a README.md and returns the page RenderReadme
+// makes of it.
+func render(t *testing.T, readme string, opts Options) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "README.md")
+	if err := os.WriteFile(path, []byte(readme), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	page, err := RenderReadme(path, opts)
+	if err != nil {
+		t.Fatalf("RenderReadme: %v", err)
+	}
+	return string(page)
+}
+
+// printRules matches the print stylesheet's media query and its light theme.
+var printRules = regexp.MustCompile(`@media (\w+) \{\s*:root \{\s*--bg-primary: #ffffff;`)
+
+func TestPrintCSS(t *testing.T) {
+	for _, tt := range []struct {
+		opts  Options
+		media string
+	}{
+		{Options{}, "print"},
+		{Options{PrintCSS: true}, "all"},
+	} {
+		page := render(t, "# Project\n", tt.opts)
+		m := printRules.FindStringSubmatch(page)
+		if m == nil {
+			t.Errorf("PrintCSS %v: page has no print stylesheet", tt.opts.PrintCSS)
+			continue
+		}
+		if m[1] != tt.media {
+			t.Errorf("PrintCSS %v: print rules apply to @media %s, want @media %s", tt.opts.PrintCSS, m[1], tt.media)
+		}
+	}
+}
+
+func TestEmoji(t *testing.T) {
+	readme := "Ship it :rocket:\n\nRun `echo :rocket:`\n"
+	for _, tt := range []struct {
+		opts Options
+		want string
+	}{
+		{Options{}, "<p>Ship it 🚀</p>"},
+		{Options{NoEmoji: true}, "<p>Ship it :rocket:</p>"},
+	} {
+		// The emoji extension writes emoji as character references.
+		page := html.UnescapeString(render(t, readme, tt.opts))
+		if !strings.Contains(page, tt.want) {
+			t.Errorf("NoEmoji %v: page doesn't contain %q", tt.opts.NoEmoji, tt.want)
+		}
+		if code := "<code>echo :rocket:</code>"; !strings.Contains(page, code) {
+			t.Errorf("NoEmoji %v: page doesn't contain %q", tt.opts.NoEmoji, code)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package recent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// MaxEntries is how many documentation pages are remembered. Opening another
+// one forgets the least recently opened.
+const MaxEntries = 50
+
+// Entry is a documentation page opened with "omnipath docs".
+type Entry struct {
+	Name       string    `json:"name"`
+	URL        string    `json:"url"`
+	LastOpened time.Time `json:"lastOpened"`
+	Count      int       `json:"count"` // How many times the page was opened.
+}
+
+// Path returns where the recent docs are stored, recent.json next to the
+// config file, such as ~/.config/omnipath/recent.json on Linux.
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locating config directory: %w", err)
+	}
+	return filepath.Join(dir, "omnipath", "recent.json"), nil
+}
+
+// Load returns the remembered pages, most recently opened first. It returns
+// no entries and no error if nothing was recorded yet.
+func Load() ([]Entry, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	sortEntries(entries)
+	return entries, nil
+}
+
+// Record remembers that the page at url, called name, was opened now.
+func Record(name, url string) error {
+	entries, err := Load()
+	if err != nil {
+		return err
+	}
+	found := false
+	for i := range entries {
+		if entries[i].URL == url {
+			entries[i].Name = name
+			entries[i].LastOpened = time.Now()
+			entries[i].Count++
+			found = true
+			break
+		}
+	}
+	if !found {
+		entries = append(entries, Entry{Name: name, URL: url, LastOpened: time.Now(), Count: 1})
+	}
+	sortEntries(entries)
+	if len(entries) > MaxEntries {
+		entries = entries[:MaxEntries]
+	}
+	return save(entries)
+}
+
+// Clear forgets every remembered page.
+func Clear() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// sortEntries orders entries most recently opened first.
+func sortEntries(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].LastOpened.After(entries[j].LastOpened)
+	})
+}
+
+// save writes entries to the recent docs file, replacing it atomically so a
+// concurrent Load never sees a partial file.
+func save(entries []Entry) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "recent-*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,79 @@
+package runlog
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// timestampLayout is prepended to each line when timestamps are enabled.
+const timestampLayout = "2006-01-02 15:04:05.000 "
+
+var unsafeFileChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// FileName returns the log file name used for a service, e.g.
+// "NPM Dev Script" becomes "npm-dev-script.log".
+func FileName(service string) string {
+	name := strings.Trim(unsafeFileChars.ReplaceAllString(strings.ToLower(service), "-"), "-.")
+	if name == "" {
+		name = "service"
+	}
+	return name + ".log"
+}
+
+// Writer appends a service's output to its log file. It is safe for
+// concurrent use, so stdout and stderr readers can share one Writer.
+type Writer struct {
+	mu         sync.Mutex
+	file       *os.File
+	timestamps bool
+	midLine    bool // Whether the last write ended partway through a line.
+}
+
+// Open creates dir if needed and opens the log file for service in append
+// mode. When timestamps is set, every line is prefixed with the time it was
+// written.
+func Open(dir, service string, timestamps bool) (*Writer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(filepath.Join(dir, FileName(service)), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{file: file, timestamps: timestamps}, nil
+}
+
+// Write appends p to the log file. It implements io.Writer.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.timestamps {
+		return w.file.Write(p)
+	}
+
+	var buf []byte
+	stamp := time.Now().Format(timestampLayout)
+	for _, c := range p {
+		if !w.midLine {
+			buf = append(buf, stamp...)
+			w.midLine = true
+		}
+		buf = append(buf, c)
+		if c == '\n' {
+			w.midLine = false
+		}
+	}
+	if _, err := w.file.Write(buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close closes the underlying log file.
+func (w *Writer) Close() error {
+	return w.file.Close()
+}
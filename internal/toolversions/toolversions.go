@@ -0,0 +1,90 @@
+package toolversions
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileName is the file asdf reads pinned tool versions from.
+const FileName = ".tool-versions"
+
+// Versions maps an asdf plugin name, such as "nodejs" or "golang", to the
+// versions pinned for it, preferred first.
+type Versions map[string][]string
+
+// Load reads and parses the .tool-versions file at path.
+func Load(path string) (Versions, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Parse(file)
+}
+
+// Parse reads "tool version [fallback...]" lines. Blank lines and comments
+// starting with # are skipped, as are lines naming a tool without a version.
+// A tool listed twice keeps its first line, as in asdf.
+func Parse(r io.Reader) (Versions, error) {
+	versions := make(Versions)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		if _, seen := versions[fields[0]]; !seen {
+			versions[fields[0]] = fields[1:]
+		}
+	}
+	return versions, scanner.Err()
+}
+
+// Resolve returns the versions pinned for dir the way asdf picks them: each
+// tool's version comes from the nearest .tool-versions file in dir or one of
+// its parents. It returns an empty map when there is no such file.
+func Resolve(dir string) (Versions, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	resolved := make(Versions)
+	for {
+		versions, err := Load(filepath.Join(dir, FileName))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		for tool, v := range versions {
+			if _, ok := resolved[tool]; !ok {
+				resolved[tool] = v
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return resolved, nil
+		}
+		dir = parent
+	}
+}
+
+// Matches reports whether an installed version satisfies one of the pinned
+// versions. A pin may be a prefix of the full version, so "3.12" matches
+// "3.12.1". Pins asdf doesn't resolve to a fixed release, such as "system",
+// "latest" or "ref:<commit>", match anything.
+func Matches(pinned []string, version string) bool {
+	for _, pin := range pinned {
+		switch {
+		case pin == "system", pin == "latest", strings.HasPrefix(pin, "latest:"),
+			strings.HasPrefix(pin, "ref:"), strings.HasPrefix(pin, "path:"):
+			return true
+		case pin == version, strings.HasPrefix(version, pin+"."):
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,360 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultScrollback is the number of output lines a session keeps when no
+// explicit limit is configured.
+const DefaultScrollback = 5000
+
+// liveLines is how many of the most recent lines stay editable. Programs that
+// redraw progress output move the cursor up within this window; anything older
+// is frozen into the scrollback ring.
+const liveLines = 32
+
+// maxLineCells caps the width of a single line so output that never emits a
+// newline still can't grow the buffer without bound.
+const maxLineCells = 4096
+
+// maxStyleLen bounds the accumulated SGR parameters for the current style.
+const maxStyleLen = 64
+
+// maxSeqLen bounds the parameters of a control sequence. A longer one is
+// malformed or hostile, and is abandoned rather than buffered.
+const maxSeqLen = 64
+
+// cell is a single character on a line along with the SGR style it was
+// written with.
+type cell struct {
+	r     rune
+	style string
+}
+
+// parserState tracks where we are inside an escape sequence between writes.
+type parserState int
+
+const (
+	stateGround parserState = iota
+	stateEscape
+	stateCharset
+	stateCSI
+	stateOSC
+	stateOSCEscape
+)
+
+// OutputBuffer holds the most recent output written by a session. It
+// interprets the subset of terminal control sequences commonly used by dev
+// servers (carriage returns, erase-in-line, cursor movement and SGR colors), so
+// spinners and progress bars overwrite themselves instead of flooding the
+// buffer. Once the scrollback limit is reached the oldest lines are discarded.
+type OutputBuffer struct {
+	lines []string // Ring of frozen, rendered lines.
+	start int      // Index of the oldest line in lines.
+	count int      // Number of frozen lines currently stored.
+	total int      // Number of lines ever frozen.
+
+	screen [][]cell // Editable lines, oldest first.
+	row    int      // Cursor row within screen.
+	col    int      // Cursor column within the current row.
+	style  string   // Active SGR parameters.
+
+	state   parserState
+	seq     []byte // Pending escape sequence parameters.
+	pending []byte // Incomplete UTF-8 sequence carried over between writes.
+}
+
+// NewOutputBuffer creates a buffer that retains at most maxLines lines.
+func NewOutputBuffer(maxLines int) *OutputBuffer {
+	if maxLines <= 0 {
+		maxLines = DefaultScrollback
+	}
+	return &OutputBuffer{
+		lines:  make([]string, maxLines),
+		screen: [][]cell{nil},
+	}
+}
+
+// Write interprets process output into the buffer. It implements io.Writer.
+func (b *OutputBuffer) Write(p []byte) (int, error) {
+	for _, c := range p {
+		switch b.state {
+		case stateEscape:
+			b.escape(c)
+		case stateCharset:
+			b.state = stateGround
+		case stateCSI:
+			if c >= 0x40 && c <= 0x7e {
+				b.csi(c, string(b.seq))
+				b.seq = b.seq[:0]
+				b.state = stateGround
+			} else if len(b.seq) < maxSeqLen {
+				b.seq = append(b.seq, c)
+			} else {
+				b.seq = b.seq[:0]
+				b.state = stateGround
+			}
+		case stateOSC:
+			switch c {
+			case '\a':
+				b.state = stateGround
+			case 0x1b:
+				b.state = stateOSCEscape
+			}
+		case stateOSCEscape:
+			b.state = stateGround
+		default:
+			b.ground(c)
+		}
+	}
+	return len(p), nil
+}
+
+// ground handles a byte outside of any escape sequence.
+func (b *OutputBuffer) ground(c byte) {
+	if len(b.pending) > 0 || c >= utf8.RuneSelf {
+		b.pending = append(b.pending, c)
+		if !utf8.FullRune(b.pending) {
+			return
+		}
+		r, _ := utf8.DecodeRune(b.pending)
+		b.pending = b.pending[:0]
+		b.put(r)
+		return
+	}
+	switch c {
+	case 0x1b:
+		b.state = stateEscape
+	case '\n':
+		b.newline()
+	case '\r':
+		b.col = 0
+	case '\b':
+		if b.col > 0 {
+			b.col--
+		}
+	case '\t':
+		for b.put(' '); b.col%8 != 0; {
+			b.put(' ')
+		}
+	default:
+		if c >= 0x20 && c != 0x7f {
+			b.put(rune(c))
+		}
+	}
+}
+
+// escape handles the byte following ESC.
+func (b *OutputBuffer) escape(c byte) {
+	switch c {
+	case '[':
+		b.state = stateCSI
+	case ']':
+		b.state = stateOSC
+	case '(', ')', '*', '+':
+		b.state = stateCharset
+	default:
+		b.state = stateGround
+	}
+}
+
+// csi applies a complete control sequence with the given final byte.
+func (b *OutputBuffer) csi(final byte, params string) {
+	if strings.HasPrefix(params, "?") {
+		// Private modes (cursor visibility, alternate screen) don't affect the log.
+		return
+	}
+	n := 1
+	if v, err := strconv.Atoi(strings.SplitN(params, ";", 2)[0]); err == nil && v > 0 {
+		n = v
+	}
+	switch final {
+	case 'm':
+		b.sgr(params)
+	case 'K':
+		line := b.screen[b.row]
+		switch params {
+		case "", "0":
+			if b.col < len(line) {
+				b.screen[b.row] = line[:b.col]
+			}
+		case "1":
+			// Up to and including the cursor column.
+			for i := 0; i <= b.col && i < len(line); i++ {
+				line[i] = cell{r: ' '}
+			}
+		case "2":
+			b.screen[b.row] = line[:0]
+		}
+	case 'G':
+		b.col = n - 1
+	case 'C':
+		b.col += n
+	case 'D':
+		b.col -= n
+		if b.col < 0 {
+			b.col = 0
+		}
+	case 'A':
+		b.row -= n
+		if b.row < 0 {
+			b.row = 0
+		}
+	case 'B':
+		for ; n > 0; n-- {
+			col := b.col
+			b.newline()
+			b.col = col
+		}
+	}
+	if b.col > maxLineCells {
+		b.col = maxLineCells
+	}
+}
+
+// sgr updates the active style from a "Select Graphic Rendition" sequence.
+func (b *OutputBuffer) sgr(params string) {
+	if params == "" || params == "0" {
+		b.style = ""
+		return
+	}
+	// A reset within the sequence discards everything that came before it.
+	parts := strings.Split(params, ";")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if parts[i] == "0" || parts[i] == "" {
+			b.style = ""
+			params = strings.Join(parts[i+1:], ";")
+			break
+		}
+	}
+	if params == "" {
+		return
+	}
+	if b.style == "" || len(b.style)+len(params) > maxStyleLen {
+		b.style = params
+		return
+	}
+	b.style += ";" + params
+}
+
+// put writes a rune at the cursor, overwriting whatever was there.
+func (b *OutputBuffer) put(r rune) {
+	if b.col >= maxLineCells {
+		b.newline()
+	}
+	line := b.screen[b.row]
+	for len(line) < b.col {
+		line = append(line, cell{r: ' '})
+	}
+	c := cell{r: r, style: b.style}
+	if b.col < len(line) {
+		line[b.col] = c
+	} else {
+		line = append(line, c)
+	}
+	b.screen[b.row] = line
+	b.col++
+}
+
+// newline moves the cursor to the start of the next line, freezing the oldest
+// editable line once the live window is full.
+func (b *OutputBuffer) newline() {
+	b.col = 0
+	b.row++
+	if b.row < len(b.screen) {
+		return
+	}
+	b.screen = append(b.screen, nil)
+	if len(b.screen) > liveLines {
+		b.push(renderCells(b.screen[0]))
+		b.screen[0] = nil
+		b.screen = b.screen[1:]
+		b.row--
+	}
+}
+
+// push stores a frozen line, overwriting the oldest one when full.
+func (b *OutputBuffer) push(line string) {
+	b.total++
+	if b.count < len(b.lines) {
+		b.lines[(b.start+b.count)%len(b.lines)] = line
+		b.count++
+		return
+	}
+	b.lines[b.start] = line
+	b.start = (b.start + 1) % len(b.lines)
+}
+
+// renderCells converts a line of cells into a string with SGR sequences.
+func renderCells(cells []cell) string {
+	var sb strings.Builder
+	style := ""
+	for _, c := range cells {
+		if c.style != style {
+			if style != "" {
+				sb.WriteString("\x1b[0m")
+			}
+			if c.style != "" {
+				sb.WriteString("\x1b[" + c.style + "m")
+			}
+			style = c.style
+		}
+		sb.WriteRune(c.r)
+	}
+	if style != "" {
+		sb.WriteString("\x1b[0m")
+	}
+	return sb.String()
+}
+
+// liveCount returns how many editable lines are visible, ignoring an empty
+// line the cursor has just moved onto.
+func (b *OutputBuffer) liveCount() int {
+	n := len(b.screen)
+	if n > 0 && len(b.screen[n-1]) == 0 {
+		n--
+	}
+	return n
+}
+
+// Lines returns the buffered lines from oldest to newest, including the
+// editable lines still being written.
+func (b *OutputBuffer) Lines() []string {
+	live := b.liveCount()
+	out := make([]string, 0, b.count+live)
+	for i := 0; i < b.count; i++ {
+		out = append(out, b.lines[(b.start+i)%len(b.lines)])
+	}
+	for _, line := range b.screen[:live] {
+		out = append(out, renderCells(line))
+	}
+	return out
+}
+
+// Len returns the number of lines Lines would return.
+func (b *OutputBuffer) Len() int {
+	return b.count + b.liveCount()
+}
+
+// Offset returns the absolute index of the oldest retained line, i.e. how many
+// lines have been discarded since the buffer was created. Callers can use it
+// to keep a position stable while older lines scroll out of the buffer.
+func (b *OutputBuffer) Offset() int {
+	return b.total - b.count
+}
+
+// Clear discards all buffered lines, including the ones still being written.
+// Absolute line numbers keep counting from where they were, so Offset never
+// goes backwards.
+func (b *OutputBuffer) Clear() {
+	b.start, b.count = 0, 0
+	b.screen = [][]cell{nil}
+	b.row, b.col = 0, 0
+}
+
+// String returns the buffered output joined by newlines.
+func (b *OutputBuffer) String() string {
+	return strings.Join(b.Lines(), "\n")
+}
@@ -0,0 +1,28 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEraseToCursor(t *testing.T) {
+	b := NewOutputBuffer(10)
+	b.Write([]byte("abcdef\x1b[3G\x1b[1K"))
+	if got, want := b.String(), "   def"; got != want {
+		t.Errorf("after CSI 1K at column 3, line = %q, want %q", got, want)
+	}
+}
+
+func TestLongControlSequence(t *testing.T) {
+	b := NewOutputBuffer(10)
+	b.Write([]byte("\x1b[" + strings.Repeat("1;", 100000)))
+	if len(b.seq) > maxSeqLen {
+		t.Errorf("buffered %d bytes of a control sequence, want at most %d", len(b.seq), maxSeqLen)
+	}
+	// Output after the abandoned sequence is interpreted as usual.
+	b.Write([]byte("\r\x1b[2K\x1b[32mdone\x1b[0m"))
+	lines := b.Lines()
+	if got, want := lines[len(lines)-1], "\x1b[32mdone\x1b[0m"; got != want {
+		t.Errorf("last line = %q, want %q", got, want)
+	}
+}
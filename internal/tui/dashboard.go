@@ -0,0 +1,215 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adammpkins/OmniPath/internal/docs"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Overview is what the dashboard shows about the project in the current
+// directory. Empty fields are shown as not detected.
+type Overview struct {
+	Project      string // Name of the project directory.
+	RepoURL      string // Web URL of the git remote.
+	Branch       string
+	Services     []Service
+	Dependencies []docs.DependencyDocs
+}
+
+// ActionKind is the quick action chosen on the dashboard.
+type ActionKind int
+
+const (
+	ActionNone     ActionKind = iota // The user quit.
+	ActionRun                        // Run Action.Service.
+	ActionOpenDoc                    // Open Action.Dependency's documentation.
+	ActionOpenRepo                   // Open the repository.
+)
+
+// Action is the quick action the user chose on the dashboard. The caller
+// performs it once the dashboard has exited and given back the terminal.
+type Action struct {
+	Kind       ActionKind
+	Service    Service
+	Dependency docs.DependencyDocs
+}
+
+// Dashboard panels, in the order tab cycles through them.
+const (
+	panelServices = iota
+	panelDependencies
+	panelCount
+)
+
+var (
+	dashboardTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4"))
+	panelStyle          = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+	focusedPanelColor   = lipgloss.Color("#7D56F4")
+	cursorStyle         = lipgloss.NewStyle().Bold(true)
+	dimStyle            = lipgloss.NewStyle().Faint(true)
+)
+
+// dashboardModel shows the services and dependencies of a project side by
+// side, with the git remote above them.
+type dashboardModel struct {
+	overview Overview
+	focus    int
+	cursors  [panelCount]int
+	action   Action
+	width    int
+	height   int
+}
+
+func newDashboardModel(overview Overview) *dashboardModel {
+	overview.Dependencies = append([]docs.DependencyDocs(nil), overview.Dependencies...)
+	docs.Sort(overview.Dependencies)
+	m := &dashboardModel{overview: overview, width: 80, height: 24}
+	if len(overview.Services) == 0 && len(overview.Dependencies) > 0 {
+		m.focus = panelDependencies
+	}
+	return m
+}
+
+func (m *dashboardModel) Init() tea.Cmd {
+	return nil
+}
+
+// panelLen returns the number of items in a panel.
+func (m *dashboardModel) panelLen(panel int) int {
+	if panel == panelServices {
+		return len(m.overview.Services)
+	}
+	return len(m.overview.Dependencies)
+}
+
+func (m *dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "tab", "right", "l":
+			m.focus = (m.focus + 1) % panelCount
+		case "shift+tab", "left", "h":
+			m.focus = (m.focus + panelCount - 1) % panelCount
+		case "up", "k":
+			if m.cursors[m.focus] > 0 {
+				m.cursors[m.focus]--
+			}
+		case "down", "j":
+			if m.cursors[m.focus] < m.panelLen(m.focus)-1 {
+				m.cursors[m.focus]++
+			}
+		case "o":
+			if m.overview.RepoURL != "" {
+				m.action = Action{Kind: ActionOpenRepo}
+				return m, tea.Quit
+			}
+		case "enter":
+			if m.panelLen(m.focus) == 0 {
+				break
+			}
+			i := m.cursors[m.focus]
+			if m.focus == panelServices {
+				m.action = Action{Kind: ActionRun, Service: m.overview.Services[i]}
+			} else {
+				m.action = Action{Kind: ActionOpenDoc, Dependency: m.overview.Dependencies[i]}
+			}
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m *dashboardModel) View() string {
+	var b strings.Builder
+	project := m.overview.Project
+	if project == "" {
+		project = "project"
+	}
+	b.WriteString(dashboardTitleStyle.Render("OmniPath · "+project) + "\n")
+	repo := dimStyle.Render("no git remote")
+	if m.overview.RepoURL != "" {
+		repo = m.overview.RepoURL
+	}
+	if m.overview.Branch != "" {
+		repo += dimStyle.Render(" on " + m.overview.Branch)
+	}
+	b.WriteString("Repository: " + repo + "\n\n")
+
+	// Two panels side by side, each with a border and padding.
+	panelWidth := max((m.width-2)/2-4, 20)
+	panelHeight := max(m.height-7, 3)
+
+	var services []string
+	for _, s := range m.overview.Services {
+		services = append(services, s.Name)
+	}
+	var deps []string
+	for _, d := range m.overview.Dependencies {
+		label := categoryLabels[d.Category]
+		if label == "" {
+			label = "Other"
+		}
+		deps = append(deps, d.Name+dimStyle.Render(" · "+label))
+	}
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
+		m.renderPanel(panelServices, "Services", services, "no services detected", panelWidth, panelHeight),
+		" ",
+		m.renderPanel(panelDependencies, "Dependencies", deps, "no dependencies detected", panelWidth, panelHeight),
+	))
+
+	help := "tab: switch panel • ↑/↓: move • enter: run / open docs • q: quit"
+	if m.overview.RepoURL != "" {
+		help = "tab: switch panel • ↑/↓: move • enter: run / open docs • o: open repo • q: quit"
+	}
+	b.WriteString("\n" + dimStyle.Render(ansi.Truncate(help, m.width, "…")))
+	return b.String()
+}
+
+// renderPanel draws a bordered panel listing lines, scrolled so the cursor
+// stays visible and highlighted when the panel has focus.
+func (m *dashboardModel) renderPanel(panel int, title string, lines []string, empty string, width, height int) string {
+	rows := []string{lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("%s (%d)", title, len(lines)))}
+	if len(lines) == 0 {
+		rows = append(rows, dimStyle.Render(empty))
+	}
+	visible := height - 1
+	cursor := m.cursors[panel]
+	start := 0
+	if cursor >= visible {
+		start = cursor - visible + 1
+	}
+	for i := start; i < len(lines) && i < start+visible; i++ {
+		line := "  " + lines[i]
+		if i == cursor && panel == m.focus {
+			line = cursorStyle.Render("> ") + lines[i]
+		}
+		rows = append(rows, ansi.Truncate(line, width, "…"))
+	}
+	style := panelStyle.Width(width).Height(height)
+	if panel == m.focus {
+		style = style.BorderForeground(focusedPanelColor)
+	}
+	return style.Render(strings.Join(rows, "\n"))
+}
+
+// RunDashboard shows the project overview until the user quits or picks a
+// quick action, which is returned for the caller to perform.
+func RunDashboard(overview Overview) (Action, error) {
+	finalModel, err := RunProgram(tea.NewProgram(newDashboardModel(overview), tea.WithAltScreen()))
+	if err != nil {
+		return Action{}, err
+	}
+	m, ok := finalModel.(*dashboardModel)
+	if !ok {
+		return Action{}, fmt.Errorf("unexpected model type")
+	}
+	return m.action, nil
+}
@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// helpBoxStyle frames the key help overlay.
+var helpBoxStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("#7D56F4")).
+	Padding(0, 1)
+
+// HelpOverlay draws the full help of keys in a box centered over view, which
+// is what a width by height terminal currently shows. toggle is the binding
+// that closes the help again.
+func HelpOverlay(view string, keys help.KeyMap, toggle key.Binding, width, height int) string {
+	h := help.New()
+	h.ShowAll = true
+	h.Width = max(width-4, 0)
+	box := helpBoxStyle.Render(lipgloss.NewStyle().Bold(true).Render("Keys") + "\n\n" +
+		h.View(keys) + "\n\n" + h.Styles.FullDesc.Render("Press "+toggle.Help().Key+" or esc to close."))
+	return overlay(view, box, width, height)
+}
+
+// overlay draws box over the center of base, keeping the parts of base's
+// lines to the left and right of it.
+func overlay(base, box string, width, height int) string {
+	lines := strings.Split(base, "\n")
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	boxLines := strings.Split(box, "\n")
+	boxWidth := lipgloss.Width(box)
+	x := max((width-boxWidth)/2, 0)
+	y := max((height-len(boxLines))/2, 0)
+	for i, boxLine := range boxLines {
+		row := y + i
+		if row >= len(lines) {
+			lines = append(lines, "")
+		}
+		left := ansi.Truncate(lines[row], x, "")
+		if pad := x - ansi.StringWidth(left); pad > 0 {
+			left += strings.Repeat(" ", pad)
+		}
+		right := ansi.TruncateLeft(lines[row], x+boxWidth, "")
+		// Reset after each part so styles don't bleed into the next one.
+		lines[row] = left + ansi.ResetStyle + boxLine + ansi.ResetStyle + right
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keyNames maps the names keys may be given by in the config file to the
+// strings Bubble Tea reports for them.
+var keyNames = map[string]string{
+	"space": " ",
+}
+
+// keySymbols are shown in the help instead of these keys' names.
+var keySymbols = map[string]string{
+	" ":     "space",
+	"up":    "↑",
+	"down":  "↓",
+	"left":  "←",
+	"right": "→",
+
+	"ctrl+left":  "ctrl+←",
+	"ctrl+right": "ctrl+→",
+}
+
+// Remap rebinds actions, keyed by their name in the config file, to the keys
+// in overrides. Actions missing from overrides keep their keys. It fails on
+// unknown actions, actions left without keys, and keys bound to more than one
+// action, without changing any binding.
+func Remap(actions map[string]*key.Binding, overrides map[string][]string) error {
+	names := make([]string, 0, len(actions))
+	for name := range actions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	remapped := make(map[string][]string)
+	for action, keys := range overrides {
+		if _, ok := actions[action]; !ok {
+			return fmt.Errorf("unknown action %q; valid actions are %s", action, strings.Join(names, ", "))
+		}
+		if len(keys) == 0 {
+			return fmt.Errorf("action %q has no keys", action)
+		}
+		for i, k := range keys {
+			if name, ok := keyNames[k]; ok {
+				keys[i] = name
+			}
+		}
+		remapped[action] = keys
+	}
+
+	owner := make(map[string]string)
+	for _, action := range names {
+		keys, ok := remapped[action]
+		if !ok {
+			keys = actions[action].Keys()
+		}
+		for _, k := range keys {
+			if other, taken := owner[k]; taken {
+				return fmt.Errorf("key %q is bound to both %s and %s", k, other, action)
+			}
+			owner[k] = action
+		}
+	}
+
+	for action, keys := range remapped {
+		b := actions[action]
+		b.SetKeys(keys...)
+		b.SetHelp(KeyHelp(keys), b.Help().Desc)
+	}
+	return nil
+}
+
+// KeyHelp returns how keys are shown in the help, e.g. "↑/k".
+func KeyHelp(keys []string) string {
+	shown := make([]string, len(keys))
+	for i, k := range keys {
+		shown[i] = k
+		if symbol, ok := keySymbols[k]; ok {
+			shown[i] = symbol
+		}
+	}
+	return strings.Join(shown, "/")
+}
@@ -5,8 +5,11 @@ import (
 	"io"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // multiSelectItem wraps Service with a Selected flag.
@@ -31,10 +34,14 @@ func (m multiSelectItem) FilterValue() string {
 	return m.Service.Name
 }
 
-// multiSelectDelegate is a custom delegate for rendering items.
+// commandStyle dims the command line shown beneath each service name.
+var commandStyle = lipgloss.NewStyle().Faint(true)
+
+// multiSelectDelegate is a custom delegate for rendering items. Each item
+// takes two lines: the checkbox and name, then the command it will run.
 type multiSelectDelegate struct{}
 
-func (d multiSelectDelegate) Height() int                               { return 1 }
+func (d multiSelectDelegate) Height() int                               { return 2 }
 func (d multiSelectDelegate) Spacing() int                              { return 0 }
 func (d multiSelectDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd { return nil }
 func (d multiSelectDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
@@ -43,7 +50,78 @@ func (d multiSelectDelegate) Render(w io.Writer, m list.Model, index int, item l
 		cursor = "> "
 	}
 	if mi, ok := item.(multiSelectItem); ok {
-		_, _ = fmt.Fprint(w, cursor+mi.Title())
+		// Long commands are truncated to the list width so they stay on one line.
+		command := ansi.Truncate(mi.Description(), m.Width()-6, "…")
+		_, _ = fmt.Fprint(w, cursor+mi.Title()+"\n      "+commandStyle.Render(command))
+	}
+}
+
+// multiSelectKeyMap holds the keys of the service selector. Navigation and
+// filtering are handled by the list itself.
+type multiSelectKeyMap struct {
+	Up      key.Binding
+	Down    key.Binding
+	Toggle  key.Binding
+	All     key.Binding
+	None    key.Binding
+	Invert  key.Binding
+	Filter  key.Binding
+	Confirm key.Binding
+	Help    key.Binding
+	Quit    key.Binding
+}
+
+var multiSelectKeys = multiSelectKeyMap{
+	Up:      key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+	Down:    key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
+	Toggle:  key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "toggle")),
+	All:     key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "select all")),
+	None:    key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "select none")),
+	Invert:  key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "invert selection")),
+	Filter:  key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter")),
+	Confirm: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "run selected")),
+	Help:    key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "toggle help")),
+	Quit:    key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+}
+
+// actions returns the bindings keyed by their name in the config file.
+func (k *multiSelectKeyMap) actions() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":          &k.Up,
+		"down":        &k.Down,
+		"toggle":      &k.Toggle,
+		"select_all":  &k.All,
+		"select_none": &k.None,
+		"invert":      &k.Invert,
+		"filter":      &k.Filter,
+		"confirm":     &k.Confirm,
+		"help":        &k.Help,
+		"quit":        &k.Quit,
+	}
+}
+
+// SetSelectKeys rebinds the service selector's keys, as configured under
+// keys.select in the config file. See Remap.
+func SetSelectKeys(overrides map[string][]string) error {
+	k := multiSelectKeys
+	if err := Remap(k.actions(), overrides); err != nil {
+		return err
+	}
+	multiSelectKeys = k
+	return nil
+}
+
+// ShortHelp implements help.KeyMap.
+func (k multiSelectKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Toggle, k.Confirm, k.Help, k.Quit}
+}
+
+// FullHelp implements help.KeyMap, grouping the keys into columns.
+func (k multiSelectKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Filter},
+		{k.Toggle, k.All, k.None, k.Invert},
+		{k.Confirm, k.Help, k.Quit},
 	}
 }
 
@@ -52,6 +130,10 @@ type multiSelectModel struct {
 	list         list.Model
 	selected     []Service
 	instructions string
+	showHelp     bool // Whether the key help is drawn over the view.
+	width        int  // Terminal width, or 0 until the terminal reports its size.
+	height       int  // Terminal height, or 0 until the terminal reports its size.
+	listHeight   int  // Height the list needs to show every item at once.
 }
 
 func NewMultiSelectModel(services []Service) *multiSelectModel {
@@ -59,16 +141,33 @@ func NewMultiSelectModel(services []Service) *multiSelectModel {
 	for i, s := range services {
 		items[i] = multiSelectItem{Service: s, Selected: false}
 	}
-	height := len(items) + 2
+	height := len(items)*multiSelectDelegate{}.Height() + 2
 	if height < 20 {
 		height = 20
 	}
 	delegate := multiSelectDelegate{}
-	l := list.New(items, delegate, 40, height)
+	l := list.New(items, delegate, 80, height)
 	l.Title = "Select Services to Run"
+	// The list handles navigation, filtering and quitting itself, with the
+	// same, possibly remapped, keys. Its own help only knows those, so ?
+	// shows ours.
+	l.KeyMap.CursorUp = multiSelectKeys.Up
+	l.KeyMap.CursorDown = multiSelectKeys.Down
+	l.KeyMap.Filter = multiSelectKeys.Filter
+	l.KeyMap.Quit = multiSelectKeys.Quit
+	l.KeyMap.ForceQuit.SetEnabled(false)
+	l.KeyMap.ShowFullHelp.SetEnabled(false)
+	l.KeyMap.CloseFullHelp.SetEnabled(false)
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{multiSelectKeys.Toggle, multiSelectKeys.Help}
+	}
 	return &multiSelectModel{
-		list:         l,
-		instructions: "Use ↑/↓ to navigate, SPACE to toggle selection, and ENTER to confirm.",
+		list:       l,
+		listHeight: height,
+		instructions: fmt.Sprintf("Use %s and %s to navigate, %s or a click to toggle selection, %s/%s/%s to select all/none/invert, and %s to confirm.",
+			multiSelectKeys.Up.Help().Key, multiSelectKeys.Down.Help().Key, multiSelectKeys.Toggle.Help().Key,
+			multiSelectKeys.All.Help().Key, multiSelectKeys.None.Help().Key, multiSelectKeys.Invert.Help().Key,
+			multiSelectKeys.Confirm.Help().Key),
 	}
 }
 
@@ -78,22 +177,52 @@ func (m *multiSelectModel) Init() tea.Cmd {
 
 func (m *multiSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.resize(msg.Width, msg.Height)
+		return m, nil
+	case tea.MouseMsg:
+		if !m.showHelp {
+			m.handleMouse(msg)
+		}
+		return m, nil
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
+		// While the help is shown, keys only close it or quit.
+		if m.showHelp {
+			switch {
+			case key.Matches(msg, multiSelectKeys.Help), msg.Type == tea.KeyEsc:
+				m.showHelp = false
+			case key.Matches(msg, multiSelectKeys.Quit):
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+		// Keys typed into the filter belong to it.
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch {
+		case key.Matches(msg, multiSelectKeys.Help):
+			m.showHelp = true
+			return m, nil
+		case key.Matches(msg, multiSelectKeys.Quit):
 			return m, tea.Quit
-		case "up", "k", "down", "j":
+		case key.Matches(msg, multiSelectKeys.Up), key.Matches(msg, multiSelectKeys.Down):
 			var cmd tea.Cmd
 			m.list, cmd = m.list.Update(msg)
 			return m, cmd
-		case " ":
-			i := m.list.Cursor()
-			if item, ok := m.list.Items()[i].(multiSelectItem); ok {
-				item.Selected = !item.Selected
-				m.list.SetItem(i, item)
-			}
+		case key.Matches(msg, multiSelectKeys.Toggle):
+			m.toggle(m.list.Index())
+			return m, nil
+		case key.Matches(msg, multiSelectKeys.All):
+			m.setSelected(func(bool) bool { return true })
+			return m, nil
+		case key.Matches(msg, multiSelectKeys.None):
+			m.setSelected(func(bool) bool { return false })
+			return m, nil
+		case key.Matches(msg, multiSelectKeys.Invert):
+			m.setSelected(func(selected bool) bool { return !selected })
 			return m, nil
-		case "enter":
+		case key.Matches(msg, multiSelectKeys.Confirm):
 			var selected []Service
 			for _, item := range m.list.Items() {
 				if mi, ok := item.(multiSelectItem); ok && mi.Selected {
@@ -114,18 +243,113 @@ func (m *multiSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// resize fits the list to the terminal: the full width, and the height left
+// over after the instructions and footer, but no taller than the items need.
+func (m *multiSelectModel) resize(width, height int) {
+	m.width, m.height = width, height
+	// The instructions (possibly wrapped), the count line, a blank line and
+	// the two-line footer.
+	chrome := lipgloss.Height(m.wrappedInstructions()) + 4
+	listHeight := height - chrome
+	if listHeight > m.listHeight {
+		listHeight = m.listHeight
+	}
+	if listHeight < 1 {
+		listHeight = 1
+	}
+	m.list.SetSize(width, listHeight)
+}
+
+// wrappedInstructions returns the instructions wrapped to the terminal width.
+func (m *multiSelectModel) wrappedInstructions() string {
+	if m.width == 0 {
+		return m.instructions
+	}
+	return lipgloss.NewStyle().Width(m.width).Render(m.instructions)
+}
+
+// toggle flips the Selected flag of the item at index.
+func (m *multiSelectModel) toggle(index int) {
+	if item, ok := m.list.Items()[index].(multiSelectItem); ok {
+		item.Selected = !item.Selected
+		m.list.SetItem(index, item)
+	}
+}
+
+// handleMouse toggles the item that was clicked and moves the cursor with the
+// wheel.
+func (m *multiSelectModel) handleMouse(msg tea.MouseMsg) {
+	if msg.Action != tea.MouseActionPress {
+		return
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.list.CursorUp()
+	case tea.MouseButtonWheelDown:
+		m.list.CursorDown()
+	case tea.MouseButtonLeft:
+		if index, ok := m.itemAt(msg.Y); ok {
+			m.list.Select(index)
+			m.toggle(index)
+		}
+	}
+}
+
+// itemAt returns the index of the item drawn on line y of the view.
+func (m *multiSelectModel) itemAt(y int) (int, bool) {
+	// The instructions, the count line and a blank line, then the list's
+	// title and status bars.
+	top := lipgloss.Height(m.wrappedInstructions()) + 2 +
+		lipgloss.Height(m.list.Styles.TitleBar.Render(m.list.Title)) +
+		lipgloss.Height(m.list.Styles.StatusBar.Render(""))
+	if y < top {
+		return 0, false
+	}
+	row := (y - top) / multiSelectDelegate{}.Height()
+	if row >= m.list.Paginator.ItemsOnPage(len(m.list.VisibleItems())) {
+		return 0, false
+	}
+	return m.list.Paginator.Page*m.list.Paginator.PerPage + row, true
+}
+
+// setSelected updates the Selected flag of every item using fn, which receives
+// the item's current state.
+func (m *multiSelectModel) setSelected(fn func(selected bool) bool) {
+	for i, item := range m.list.Items() {
+		if mi, ok := item.(multiSelectItem); ok {
+			mi.Selected = fn(mi.Selected)
+			m.list.SetItem(i, mi)
+		}
+	}
+}
+
+// selectedCount returns how many items are currently selected.
+func (m *multiSelectModel) selectedCount() int {
+	count := 0
+	for _, item := range m.list.Items() {
+		if mi, ok := item.(multiSelectItem); ok && mi.Selected {
+			count++
+		}
+	}
+	return count
+}
+
 func (m *multiSelectModel) View() string {
 	var b strings.Builder
-	b.WriteString(m.instructions + "\n\n")
+	b.WriteString(m.wrappedInstructions() + "\n")
+	b.WriteString(fmt.Sprintf("Selected: %d of %d\n\n", m.selectedCount(), len(m.list.Items())))
 	b.WriteString(m.list.View())
-	b.WriteString("\nPress q to quit.\n")
+	b.WriteString("\nPress " + multiSelectKeys.Quit.Help().Key + " to quit.\n")
+	if m.showHelp {
+		return HelpOverlay(b.String(), multiSelectKeys, multiSelectKeys.Help, max(m.width, 80), max(m.height, lipgloss.Height(b.String())))
+	}
 	return b.String()
 }
 
 func RunMultiSelect(services []Service) ([]Service, error) {
 	model := NewMultiSelectModel(services)
-	p := tea.NewProgram(model)
-	finalModel, err := p.Run()
+	p := tea.NewProgram(model, tea.WithMouseCellMotion())
+	finalModel, err := RunProgram(p)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,175 @@
+package multiplexer
+
+import (
+	"strings"
+
+	"github.com/adammpkins/OmniPath/internal/tui"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyMap holds the multiplexer's own keys. Keys with Ctrl or Alt, and the
+// page keys, work directly; every other key, such as a letter, only works
+// after the prefix key, so that it can still be typed into the active
+// session. Any key that isn't a command is sent to the active session.
+type keyMap struct {
+	Prefix      key.Binding
+	Prev        key.Binding
+	Next        key.Binding
+	Single      key.Binding
+	Split       key.Binding
+	PageUp      key.Binding
+	PageDown    key.Binding
+	Search      key.Binding
+	NextMatch   key.Binding
+	PrevMatch   key.Binding
+	ClearSearch key.Binding
+	Clear       key.Binding
+	Copy        key.Binding
+	Restart     key.Binding
+	Help        key.Binding
+	Quit        key.Binding
+}
+
+var keys = keyMap{
+	Prefix:      key.NewBinding(key.WithKeys("ctrl+g"), key.WithHelp("ctrl+g", "prefix; twice sends it")),
+	Prev:        key.NewBinding(key.WithKeys("ctrl+left", "h"), key.WithHelp("ctrl+←/h", "previous session")),
+	Next:        key.NewBinding(key.WithKeys("ctrl+right", "l"), key.WithHelp("ctrl+→/l", "next session")),
+	Single:      key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "show active session")),
+	Split:       key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "split view")),
+	PageUp:      key.NewBinding(key.WithKeys("pgup"), key.WithHelp("pgup", "scroll back")),
+	PageDown:    key.NewBinding(key.WithKeys("pgdown"), key.WithHelp("pgdn", "scroll forward")),
+	Search:      key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search output")),
+	NextMatch:   key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "next match")),
+	PrevMatch:   key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "previous match")),
+	ClearSearch: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "end search")),
+	Clear:       key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "clear output")),
+	Copy:        key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "copy output")),
+	Restart:     key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "restart exited service")),
+	Help:        key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "toggle help")),
+	Quit:        key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+}
+
+// actions returns the bindings keyed by their name in the config file.
+func (k *keyMap) actions() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"prefix":       &k.Prefix,
+		"prev_session": &k.Prev,
+		"next_session": &k.Next,
+		"single_view":  &k.Single,
+		"split_view":   &k.Split,
+		"page_up":      &k.PageUp,
+		"page_down":    &k.PageDown,
+		"search":       &k.Search,
+		"next_match":   &k.NextMatch,
+		"prev_match":   &k.PrevMatch,
+		"end_search":   &k.ClearSearch,
+		"clear":        &k.Clear,
+		"copy":         &k.Copy,
+		"restart":      &k.Restart,
+		"help":         &k.Help,
+		"quit":         &k.Quit,
+	}
+}
+
+// SetKeys rebinds the multiplexer's keys, as configured under
+// keys.multiplexer in the config file. See tui.Remap.
+func SetKeys(overrides map[string][]string) error {
+	k := keys
+	if err := tui.Remap(k.actions(), overrides); err != nil {
+		return err
+	}
+	keys = k
+	return nil
+}
+
+// direct reports whether k works without the prefix key: keys with a
+// modifier, and the page keys, aren't typed into prompts.
+func direct(k string) bool {
+	return strings.HasPrefix(k, "ctrl+") || strings.HasPrefix(k, "alt+") || k == "pgup" || k == "pgdown"
+}
+
+// helpKey returns how b's keys are typed, with the prefix key before those
+// that need it, e.g. "ctrl+→/ctrl+g l".
+func helpKey(b key.Binding) string {
+	prefix := keys.Prefix.Help().Key
+	shown := make([]string, len(b.Keys()))
+	for i, k := range b.Keys() {
+		shown[i] = tui.KeyHelp([]string{k})
+		if !direct(k) {
+			shown[i] = prefix + " " + shown[i]
+		}
+	}
+	return strings.Join(shown, "/")
+}
+
+// withPrefix returns a copy of bindings showing their keys as helpKey does.
+func withPrefix(bindings ...key.Binding) []key.Binding {
+	shown := make([]key.Binding, len(bindings))
+	for i, b := range bindings {
+		shown[i] = b
+		shown[i].SetHelp(helpKey(b), b.Help().Desc)
+	}
+	return shown
+}
+
+// ShortHelp implements help.KeyMap.
+func (k keyMap) ShortHelp() []key.Binding {
+	return withPrefix(k.Prev, k.Next, k.Search, k.Help, k.Quit)
+}
+
+// FullHelp implements help.KeyMap, grouping the keys into columns.
+func (k keyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		append([]key.Binding{k.Prefix}, withPrefix(k.Prev, k.Next, k.Single, k.Split, k.PageUp, k.PageDown)...),
+		// Ending a search with esc works directly while a search is shown.
+		append(withPrefix(k.Search, k.NextMatch, k.PrevMatch), k.ClearSearch),
+		withPrefix(k.Clear, k.Copy, k.Restart, k.Help, k.Quit),
+	}
+}
+
+// keySequences maps special keys to the bytes a terminal would send for them.
+// The plain arrow keys belong to the session, so session switching uses Ctrl
+// with the arrows instead.
+var keySequences = map[tea.KeyType]string{
+	tea.KeySpace:    " ",
+	tea.KeyUp:       "\x1b[A",
+	tea.KeyDown:     "\x1b[B",
+	tea.KeyRight:    "\x1b[C",
+	tea.KeyLeft:     "\x1b[D",
+	tea.KeyHome:     "\x1b[H",
+	tea.KeyEnd:      "\x1b[F",
+	tea.KeyInsert:   "\x1b[2~",
+	tea.KeyDelete:   "\x1b[3~",
+	tea.KeyShiftTab: "\x1b[Z",
+}
+
+// keyBytes translates a key press into the byte sequence that should be
+// written to a session's stdin, or nil if the key has no byte representation.
+// terminal reports whether the session runs on a pseudo-terminal.
+func keyBytes(msg tea.KeyMsg, terminal bool) []byte {
+	var seq string
+	switch {
+	case msg.Type == tea.KeyRunes:
+		seq = string(msg.Runes)
+	case msg.Type == tea.KeyEnter && terminal:
+		// A terminal sends a carriage return, which the pseudo-terminal's line
+		// discipline turns into a newline unless the program asked for raw input.
+		seq = "\r"
+	case msg.Type == tea.KeyEnter:
+		// A pipe has no line discipline, so send the newline that line-based
+		// prompts wait for.
+		seq = "\n"
+	case keySequences[msg.Type] != "":
+		seq = keySequences[msg.Type]
+	case msg.Type >= tea.KeyCtrlAt && msg.Type <= tea.KeyCtrlUnderscore, msg.Type == tea.KeyBackspace:
+		// Control keys, Tab, Esc and Backspace are their own ASCII codes.
+		seq = string(rune(msg.Type))
+	default:
+		return nil
+	}
+	if msg.Alt {
+		seq = "\x1b" + seq
+	}
+	return []byte(seq)
+}
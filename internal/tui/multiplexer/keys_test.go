@@ -0,0 +1,103 @@
+package multiplexer
+
+import (
+	"bytes"
+	"os/exec"
+	"testing"
+
+	"github.com/adammpkins/OmniPath/internal/tui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recorder collects the input sent to a session.
+type recorder struct{ bytes.Buffer }
+
+func (r *recorder) Close() error { return nil }
+
+func newTestModel(t *testing.T, names ...string) (*multiplexerModel, []*recorder) {
+	t.Helper()
+	var sessions []*tui.Session
+	var inputs []*recorder
+	for _, name := range names {
+		s := tui.NewSession(tui.Service{Name: name, Interactive: true}, 100)
+		in := &recorder{}
+		s.Attach(exec.Command("true"), in)
+		sessions = append(sessions, s)
+		inputs = append(inputs, in)
+	}
+	return NewMultiplexerModel(sessions, Options{}), inputs
+}
+
+func press(m *multiplexerModel, msgs ...tea.KeyMsg) {
+	for _, msg := range msgs {
+		m.Update(msg)
+	}
+}
+
+func runes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestLettersAreSentToTheSession(t *testing.T) {
+	m, inputs := newTestModel(t, "web", "worker")
+	for _, r := range "s/nNcyrq?hl" {
+		press(m, runes(string(r)))
+	}
+	if got, want := inputs[0].String(), "s/nNcyrq?hl"; got != want {
+		t.Errorf("session received %q, want %q", got, want)
+	}
+	if m.split || m.showHelp || m.quitting || m.activeIndex != 0 {
+		t.Errorf("letters without the prefix changed the multiplexer: split=%v help=%v quitting=%v active=%d",
+			m.split, m.showHelp, m.quitting, m.activeIndex)
+	}
+}
+
+func TestPrefixedLettersAreCommands(t *testing.T) {
+	m, inputs := newTestModel(t, "web", "worker")
+	prefix := tea.KeyMsg{Type: tea.KeyCtrlG}
+
+	press(m, prefix, runes("s"))
+	if !m.split {
+		t.Error("prefix s didn't split the view")
+	}
+	press(m, prefix, runes("l"))
+	if m.activeIndex != 1 {
+		t.Errorf("prefix l left session %d active, want 1", m.activeIndex)
+	}
+	press(m, tea.KeyMsg{Type: tea.KeyCtrlLeft})
+	if m.activeIndex != 0 {
+		t.Errorf("ctrl+left left session %d active, want 0", m.activeIndex)
+	}
+	press(m, prefix, prefix)
+	if got := inputs[0].String(); got != "\x07" {
+		t.Errorf("prefix twice sent %q, want the prefix itself", got)
+	}
+}
+
+func TestArrowsAreSentToTheSession(t *testing.T) {
+	m, inputs := newTestModel(t, "web", "worker")
+	press(m, tea.KeyMsg{Type: tea.KeyLeft}, tea.KeyMsg{Type: tea.KeyRight})
+	if got, want := inputs[0].String(), "\x1b[D\x1b[C"; got != want {
+		t.Errorf("session received %q, want %q", got, want)
+	}
+	if m.activeIndex != 0 {
+		t.Errorf("arrow keys switched to session %d", m.activeIndex)
+	}
+}
+
+func TestEnterMatchesTheSessionInput(t *testing.T) {
+	for _, tt := range []struct {
+		terminal bool
+		want     string
+	}{
+		{terminal: true, want: "\r"},
+		{terminal: false, want: "\n"},
+	} {
+		m, inputs := newTestModel(t, "web")
+		m.opts.Terminal = tt.terminal
+		press(m, tea.KeyMsg{Type: tea.KeyEnter})
+		if got := inputs[0].String(); got != tt.want {
+			t.Errorf("Terminal=%v: Enter sent %q, want %q", tt.terminal, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package multiplexer
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// wheelLines is how many lines one notch of the mouse wheel scrolls.
+const wheelLines = 3
+
+// handleMouse focuses the session that was clicked, either its line in the
+// session list or its pane in the split view, and scrolls the active
+// session's output with the wheel.
+func (m *multiplexerModel) handleMouse(msg tea.MouseMsg) {
+	if msg.Action != tea.MouseActionPress {
+		return
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.scrollBy(-wheelLines)
+	case tea.MouseButtonWheelDown:
+		m.scrollBy(wheelLines)
+	case tea.MouseButtonLeft:
+		if i, ok := m.sessionAt(msg.X, msg.Y); ok && i != m.activeIndex {
+			m.activeIndex = i
+			m.search.current = -1
+		}
+	}
+}
+
+// sessionAt returns the index of the session shown at the given cell: the
+// session list line in the single view, or the pane in the split view.
+func (m *multiplexerModel) sessionAt(x, y int) (int, bool) {
+	var i int
+	switch {
+	case !m.split:
+		// The list starts below the "Sessions:" line and may begin past the
+		// first session; the lines below it aren't sessions.
+		first, n := m.listedSessions()
+		if y < 1 || y > n {
+			return 0, false
+		}
+		i = first + y - 1
+	case m.sideBySide():
+		if y < 1 {
+			// The status line.
+			return 0, false
+		}
+		width, _ := m.paneSize()
+		i = x / (width + 1)
+	default:
+		// Stacked panes taller than the terminal lose their top lines, as
+		// Bubble Tea only draws the bottom of a view that doesn't fit.
+		_, height := m.paneSize()
+		_, h := m.size()
+		y += max(1+len(m.sessions)*(height+1)-h, 0)
+		if y < 1 {
+			return 0, false
+		}
+		i = (y - 1) / (height + 1)
+	}
+	if i < 0 || i >= len(m.sessions) {
+		return 0, false
+	}
+	return i, true
+}
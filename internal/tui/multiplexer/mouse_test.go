@@ -0,0 +1,62 @@
+package multiplexer
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func click(m *multiplexerModel, x, y int) {
+	m.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+}
+
+// rowOf returns the screen row on which the view, as Bubble Tea draws it in
+// a terminal of the given height, shows text.
+func rowOf(t *testing.T, m *multiplexerModel, text string, height int) int {
+	t.Helper()
+	lines := strings.Split(ansi.Strip(m.View()), "\n")
+	if len(lines) > height {
+		lines = lines[len(lines)-height:]
+	}
+	for row, line := range lines {
+		if strings.Contains(line, text) {
+			return row
+		}
+	}
+	t.Fatalf("%q isn't on the screen", text)
+	return 0
+}
+
+func TestClickSelectsTheListedSession(t *testing.T) {
+	m, _ := newTestModel(t, sessionNames(12)...)
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m.activeIndex = 11 // The list shows a window ending at svc11.
+
+	row := rowOf(t, m, "9: svc9", 24)
+	click(m, 5, row)
+	if m.activeIndex != 9 {
+		t.Errorf("clicking svc9's line made session %d active", m.activeIndex)
+	}
+
+	click(m, 5, m.headerHeight()+3)
+	if m.activeIndex != 9 {
+		t.Errorf("clicking the output made session %d active", m.activeIndex)
+	}
+}
+
+func TestClickSelectsTheStackedPane(t *testing.T) {
+	m, _ := newTestModel(t, sessionNames(15)...)
+	m.Update(tea.WindowSizeMsg{Width: 60, Height: 24})
+	m.split = true
+
+	// Fifteen stacked panes don't fit in 24 lines, so the top ones are cut.
+	for _, i := range []int{14, 10, 6} {
+		title := " " + sessionNames(15)[i] + " "
+		click(m, 5, rowOf(t, m, title, 24))
+		if m.activeIndex != i {
+			t.Errorf("clicking the pane titled %q made session %d active", title, m.activeIndex)
+		}
+	}
+}
@@ -5,112 +5,335 @@ import (
 	"log"
 	"os/exec"
 	"strings"
-	"sync"
-	"syscall"
 	"time"
 
+	"github.com/adammpkins/OmniPath/internal/proc"
 	"github.com/adammpkins/OmniPath/internal/tui"
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
 )
 
+// scrollPos records where a session's output view is pinned. The zero value
+// follows the tail of the output.
+type scrollPos struct {
+	pinned bool
+	top    int // Absolute index of the first visible line while pinned.
+}
+
+// Options configures optional multiplexer behaviour.
+type Options struct {
+	// Restart relaunches an exited session's service in place. Restarting is
+	// disabled when nil.
+	Restart func(*tui.Session) error
+
+	// KeepOpen keeps the multiplexer running after every session has exited,
+	// so their final output can be read. Otherwise it quits on its own.
+	KeepOpen bool
+
+	// Prefix prepends each output line with the session's name in its color,
+	// as docker compose and foreman do.
+	Prefix bool
+
+	// GracePeriod is how long quitting waits after SIGINT, and then after
+	// SIGTERM, before escalating. Zero uses proc.DefaultGracePeriod.
+	GracePeriod time.Duration
+
+	// Terminal reports that sessions run on pseudo-terminals, so Enter is sent
+	// as a carriage return rather than a newline.
+	Terminal bool
+}
+
 type multiplexerModel struct {
+	opts        Options
+	status      string // Message shown below the session list until the next key press.
+	quitting    bool   // Whether the sessions are being stopped after q or Ctrl+C.
 	sessions    []*tui.Session
 	activeIndex int
-	updateCh    chan struct{}
-	mu          sync.Mutex
+	scroll      []scrollPos // Scroll position per session, indexed like sessions.
+	split       bool        // Tile all sessions instead of showing only the active one.
+	search      searchState // Search through the active session's output.
+	showHelp    bool        // Whether the key help is drawn over the view.
+	prefixed    bool        // Whether the prefix key was just pressed.
+	width       int
+	height      int
 }
 
-func NewMultiplexerModel(sessions []*tui.Session) multiplexerModel {
-	m := multiplexerModel{
+func NewMultiplexerModel(sessions []*tui.Session, opts Options) *multiplexerModel {
+	m := &multiplexerModel{
+		opts:        opts,
 		sessions:    sessions,
 		activeIndex: 0,
-		updateCh:    make(chan struct{}, 1),
+		scroll:      make([]scrollPos, len(sessions)),
+		search:      searchState{current: -1},
 	}
-	go func() {
-		for {
-			time.Sleep(200 * time.Millisecond)
-			m.triggerUpdate()
-		}
-	}()
 	return m
 }
 
-func (m *multiplexerModel) triggerUpdate() {
-	select {
-	case m.updateCh <- struct{}{}:
-	default:
+// sessionOutputMsg reports that the session at index has new output or a
+// changed process state.
+type sessionOutputMsg struct{ index int }
+
+// waitForOutput returns a command that reports the next change to the session
+// at index. It is reissued after each change, so the view is redrawn when
+// there is something new rather than on a timer.
+func (m *multiplexerModel) waitForOutput(index int) tea.Cmd {
+	changed := m.sessions[index].Changed()
+	return func() tea.Msg {
+		<-changed
+		return sessionOutputMsg{index: index}
 	}
 }
 
-func (m multiplexerModel) Init() tea.Cmd {
+// sessionExitedMsg reports that a session's process has exited.
+type sessionExitedMsg struct{}
+
+// waitForExit returns a command that reports when sess's current process exits.
+func waitForExit(sess *tui.Session) tea.Cmd {
+	done := sess.Done()
 	return func() tea.Msg {
-		<-m.updateCh
-		return struct{}{}
+		<-done
+		return sessionExitedMsg{}
 	}
 }
 
-func (m multiplexerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+// resizeSessions tells every session the size of its pane in the current
+// layout, so programs running on a terminal wrap and redraw to fit it.
+func (m *multiplexerModel) resizeSessions() {
+	w, h := m.paneSize()
+	for _, sess := range m.sessions {
+		sess.Resize(w, h)
+	}
+}
+
+// allExited reports whether every session's process has exited.
+func (m *multiplexerModel) allExited() bool {
+	for _, sess := range m.sessions {
+		if !sess.Exited() {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *multiplexerModel) Init() tea.Cmd {
+	var cmds []tea.Cmd
+	for i, sess := range m.sessions {
+		cmds = append(cmds, m.waitForOutput(i), waitForExit(sess))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *multiplexerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
 	switch msg := msg.(type) {
+	case sessionOutputMsg:
+		return m, m.waitForOutput(msg.index)
+	case stoppedMsg:
+		return m, tea.Quit
+	case sessionExitedMsg:
+		// While quitting, stopSessions decides when every group is gone.
+		if !m.quitting && !m.opts.KeepOpen && m.allExited() {
+			return m, tea.Quit
+		}
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.resizeSessions()
+	case tea.MouseMsg:
+		if !m.quitting && !m.showHelp {
+			m.handleMouse(msg)
+		}
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
-			for _, sess := range m.sessions {
-				if strings.Contains(strings.ToLower(sess.Name), "sail") {
-					log.Println("Detected Laravel Sail; running './vendor/bin/sail down'")
-					cmd := exec.Command("./vendor/bin/sail", "down")
-					if err := cmd.Run(); err != nil {
-						log.Printf("Error shutting down Laravel Sail: %v", err)
-					}
-				}
-				if sess.Cmd != nil && sess.Cmd.Process != nil {
-					if pgid, err := syscall.Getpgid(sess.Cmd.Process.Pid); err == nil {
-						syscall.Kill(-pgid, syscall.SIGINT)
-					}
-				}
+		// Once quitting, a second q or Ctrl+C kills whatever is left.
+		if m.quitting {
+			if key.Matches(msg, keys.Quit) {
+				proc.Kill(m.processGroups())
+				return m, tea.Quit
 			}
-			return m, tea.Quit
-		case "left", "h":
+			break
+		}
+		// While the help is shown, keys only close it or quit.
+		if m.showHelp {
+			switch {
+			case key.Matches(msg, keys.Help), msg.Type == tea.KeyEsc:
+				m.showHelp = false
+			case key.Matches(msg, keys.Quit):
+				m.showHelp = false
+				return m, m.quit()
+			}
+			break
+		}
+		// Status messages last until the next key press.
+		m.status = ""
+		prefixed := m.prefixed
+		m.prefixed = false
+		if m.handleSearchKey(msg, prefixed) {
+			break
+		}
+		if !prefixed && key.Matches(msg, keys.Prefix) {
+			m.prefixed = true
+			break
+		}
+		// Only keys after the prefix, or keys with a modifier, are commands.
+		matches := func(b key.Binding) bool {
+			return key.Matches(msg, b) && (prefixed || direct(msg.String()))
+		}
+		switch {
+		case matches(keys.Help):
+			m.showHelp = true
+		case matches(keys.Search):
+			m.search = searchState{typing: true, current: -1}
+		case matches(keys.PageUp):
+			m.scrollBy(-m.paneHeight())
+		case matches(keys.PageDown):
+			m.scrollBy(m.paneHeight())
+		case matches(keys.Single):
+			m.split = false
+			m.resizeSessions()
+		case matches(keys.Split):
+			m.split = true
+			m.resizeSessions()
+		case matches(keys.Quit):
+			return m, m.quit()
+		case matches(keys.Prev):
 			if m.activeIndex > 0 {
 				m.activeIndex--
+				m.search.current = -1
 			}
-		case "right", "l":
+		case matches(keys.Next):
 			if m.activeIndex < len(m.sessions)-1 {
 				m.activeIndex++
+				m.search.current = -1
 			}
-		default:
+		case matches(keys.Clear):
+			m.sessions[m.activeIndex].ClearOutput()
+			m.scroll[m.activeIndex] = scrollPos{}
+			m.search.current = -1
+			m.status = fmt.Sprintf("Cleared output of %s.", m.sessions[m.activeIndex].Name)
+		case matches(keys.Copy):
+			m.status = m.copyOutput()
+		case matches(keys.Restart):
 			active := m.sessions[m.activeIndex]
-			if active.Stdin != nil {
-				_, _ = active.Stdin.Write([]byte(msg.String()))
+			switch {
+			case m.opts.Restart == nil:
+				m.status = "Restarting is disabled."
+			case !active.Exited():
+				m.status = fmt.Sprintf("%s is still running.", active.Name)
+			default:
+				if err := m.opts.Restart(active); err != nil {
+					m.status = fmt.Sprintf("Failed to restart %s: %v", active.Name, err)
+				} else {
+					cmds = append(cmds, waitForExit(active))
+				}
+			}
+		case prefixed && !key.Matches(msg, keys.Prefix):
+			m.status = fmt.Sprintf("%s %s isn't a command; press %s for help.", keys.Prefix.Help().Key, msg.String(), helpKey(keys.Help))
+		default:
+			// Everything else, including the prefix pressed twice, is input.
+			if b := keyBytes(msg, m.opts.Terminal); b != nil {
+				_ = m.sessions[m.activeIndex].SendInput(b)
 			}
 		}
 	}
-	return m, func() tea.Msg {
-		<-m.updateCh
-		return struct{}{}
+	return m, tea.Batch(cmds...)
+}
+
+// stoppedMsg reports that every session's process group has been stopped.
+type stoppedMsg struct{}
+
+// quit starts shutting the sessions down and returns a command that reports
+// stoppedMsg once they have exited. Laravel Sail containers are stopped with
+// "sail down", since they outlive the sail process itself.
+func (m *multiplexerModel) quit() tea.Cmd {
+	m.quitting = true
+	for _, sess := range m.sessions {
+		if strings.Contains(strings.ToLower(sess.Name), "sail") {
+			log.Println("Detected Laravel Sail; running './vendor/bin/sail down'")
+			cmd := exec.Command("./vendor/bin/sail", "down")
+			if err := cmd.Run(); err != nil {
+				log.Printf("Error shutting down Laravel Sail: %v", err)
+			}
+		}
+	}
+	pgids := m.processGroups()
+	grace := m.opts.GracePeriod
+	if grace == 0 {
+		grace = proc.DefaultGracePeriod
+	}
+	return func() tea.Msg {
+		proc.Stop(pgids, grace)
+		return stoppedMsg{}
 	}
 }
 
-func (m multiplexerModel) View() string {
-	headerLines := []string{"Sessions:"}
-	for i, sess := range m.sessions {
-		marker := "  "
-		if i == m.activeIndex {
-			marker = "> "
+// processGroups returns the process group IDs of every session that has
+// been started. Sessions run in their own process group, so its ID is the
+// leader's PID, which stays valid even after the leader has exited.
+func (m *multiplexerModel) processGroups() []int {
+	var pgids []int
+	for _, sess := range m.sessions {
+		if c := sess.Cmd(); c != nil && c.Process != nil {
+			pgids = append(pgids, c.Process.Pid)
 		}
-		headerLines = append(headerLines, fmt.Sprintf("%s%d: %s", marker, i, sess.Name))
 	}
-	const headerHeight = 6
-	for len(headerLines) < headerHeight {
-		headerLines = append(headerLines, "")
+	return pgids
+}
+
+// copyOutput copies the active session's buffered output, without colors, to
+// the system clipboard and returns a status message describing the result.
+func (m *multiplexerModel) copyOutput() string {
+	active := m.sessions[m.activeIndex]
+	if clipboard.Unsupported {
+		return "Clipboard unavailable: install xclip, xsel or wl-clipboard to copy output."
+	}
+	lines := active.Snapshot().Lines
+	for i, line := range lines {
+		lines[i] = ansi.Strip(line)
+	}
+	if err := clipboard.WriteAll(strings.Join(lines, "\n")); err != nil {
+		return fmt.Sprintf("Failed to copy output: %v", err)
+	}
+	return fmt.Sprintf("Copied %d lines of %s to the clipboard.", len(lines), active.Name)
+}
+
+// scrollBy moves the active session's view by delta lines. Scrolling back to
+// the bottom resumes following new output.
+func (m *multiplexerModel) scrollBy(delta int) {
+	snap := m.sessions[m.activeIndex].Snapshot()
+	pos := &m.scroll[m.activeIndex]
+	bottom := snap.Offset + len(snap.Lines) - m.paneHeight()
+	if bottom < snap.Offset {
+		bottom = snap.Offset
+	}
+	top := bottom
+	if pos.pinned {
+		top = pos.top
+	}
+	m.scrollTo(snap, top+delta)
+}
+
+// scrollTo pins the active session's view so that top is its first visible
+// line, or follows the tail again if that would show the bottom anyway.
+func (m *multiplexerModel) scrollTo(snap tui.Snapshot, top int) {
+	pos := &m.scroll[m.activeIndex]
+	bottom := snap.Offset + len(snap.Lines) - m.paneHeight()
+	if bottom < snap.Offset {
+		bottom = snap.Offset
+	}
+	if top < snap.Offset {
+		top = snap.Offset
+	}
+	if top >= bottom {
+		*pos = scrollPos{}
+		return
 	}
-	header := strings.Join(headerLines, "\n")
-	content := header + "\n\n--- Active Session Output ---\n" + m.sessions[m.activeIndex].Output
-	return content
+	*pos = scrollPos{pinned: true, top: top}
 }
 
-func RunMultiplexer(sessions []*tui.Session) error {
-	m := NewMultiplexerModel(sessions)
-	p := tea.NewProgram(m)
-	_, err := p.Run()
+func RunMultiplexer(sessions []*tui.Session, opts Options) error {
+	m := NewMultiplexerModel(sessions, opts)
+	p := tea.NewProgram(m, tea.WithMouseCellMotion())
+	_, err := tui.RunProgram(p)
 	return err
 }
@@ -0,0 +1,174 @@
+package multiplexer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adammpkins/OmniPath/internal/tui"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	matchStyle        = lipgloss.NewStyle().Background(lipgloss.Color("220")).Foreground(lipgloss.Color("0"))
+	currentMatchStyle = lipgloss.NewStyle().Background(lipgloss.Color("208")).Foreground(lipgloss.Color("0")).Bold(true)
+)
+
+// searchState is a search through the active session's output.
+type searchState struct {
+	typing  bool   // Whether the query is still being entered.
+	query   string // The query; no search is active while it's empty.
+	current int    // Absolute index of the highlighted match, or -1 if none.
+	index   int    // 1-based position of the current match among count matches.
+	count   int
+}
+
+// handleSearchKey handles a key press that belongs to the search rather than
+// the active session, and reports whether it did. While a query is being
+// typed every key belongs to the search; otherwise moving between matches
+// needs the prefix, given by prefixed, unless bound to a key with a modifier.
+func (m *multiplexerModel) handleSearchKey(msg tea.KeyMsg, prefixed bool) bool {
+	if m.search.typing {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.search.typing = false
+			if m.search.query == "" {
+				m.search = searchState{current: -1}
+				break
+			}
+			// Start from the most recent output.
+			m.search.current = -1
+			m.jumpToMatch(-1)
+		case tea.KeyEsc, tea.KeyCtrlC:
+			m.search = searchState{current: -1}
+		case tea.KeyBackspace:
+			if r := []rune(m.search.query); len(r) > 0 {
+				m.search.query = string(r[:len(r)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			m.search.query += string(msg.Runes)
+		}
+		return true
+	}
+	if m.search.query == "" {
+		return false
+	}
+	command := prefixed || direct(msg.String())
+	switch {
+	case command && key.Matches(msg, keys.NextMatch):
+		m.jumpToMatch(1)
+	case command && key.Matches(msg, keys.PrevMatch):
+		m.jumpToMatch(-1)
+	case key.Matches(msg, keys.ClearSearch):
+		m.search = searchState{current: -1}
+	default:
+		return false
+	}
+	return true
+}
+
+// matchLines returns the absolute indexes of the lines in snap that contain
+// the query, ignoring case and colors.
+func (m *multiplexerModel) matchLines(snap tui.Snapshot) []int {
+	query := strings.ToLower(m.search.query)
+	var matches []int
+	for i, line := range snap.Lines {
+		if strings.Contains(strings.ToLower(ansi.Strip(line)), query) {
+			matches = append(matches, snap.Offset+i)
+		}
+	}
+	return matches
+}
+
+// jumpToMatch moves to the next match after the current one (dir > 0) or the
+// previous one before it (dir < 0), wrapping around, and scrolls it into view.
+func (m *multiplexerModel) jumpToMatch(dir int) {
+	snap := m.sessions[m.activeIndex].Snapshot()
+	matches := m.matchLines(snap)
+	m.search.count = len(matches)
+	if len(matches) == 0 {
+		m.search.current, m.search.index = -1, 0
+		m.status = fmt.Sprintf("No matches for %q", m.search.query)
+		return
+	}
+	m.status = ""
+
+	pick := -1
+	if dir > 0 {
+		for i, line := range matches {
+			if m.search.current == -1 || line > m.search.current {
+				pick = i
+				break
+			}
+		}
+		if pick == -1 {
+			pick = 0
+		}
+	} else {
+		for i := len(matches) - 1; i >= 0; i-- {
+			if m.search.current == -1 || matches[i] < m.search.current {
+				pick = i
+				break
+			}
+		}
+		if pick == -1 {
+			pick = len(matches) - 1
+		}
+	}
+	m.search.current = matches[pick]
+	m.search.index = pick + 1
+
+	// Show the match in the middle of the pane where possible.
+	m.scrollTo(snap, m.search.current-m.paneHeight()/2)
+}
+
+// searchHint describes the search for the status line, or returns an empty
+// string when there is none.
+func (m *multiplexerModel) searchHint() string {
+	switch {
+	case m.search.typing:
+		return "/" + m.search.query + "█"
+	case m.search.query != "" && m.search.count > 0:
+		return fmt.Sprintf("Search %q: match %d of %d - %s/%s: next/previous, %s: clear", m.search.query, m.search.index, m.search.count,
+			helpKey(keys.NextMatch), helpKey(keys.PrevMatch), keys.ClearSearch.Help().Key)
+	}
+	return ""
+}
+
+// highlightMatches marks occurrences of the query in the active session's
+// visible lines, whose first line has absolute index first. Matching lines
+// lose their own colors so the highlight stays readable.
+func (m *multiplexerModel) highlightMatches(lines []string, first int) []string {
+	if m.search.query == "" || m.search.typing {
+		return lines
+	}
+	query := strings.ToLower(m.search.query)
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		plain := ansi.Strip(line)
+		lower := strings.ToLower(plain)
+		if !strings.Contains(lower, query) || len(lower) != len(plain) {
+			out[i] = line
+			continue
+		}
+		style := matchStyle
+		if first+i == m.search.current {
+			style = currentMatchStyle
+		}
+		var b strings.Builder
+		for {
+			j := strings.Index(lower, query)
+			if j < 0 {
+				b.WriteString(plain)
+				break
+			}
+			b.WriteString(plain[:j])
+			b.WriteString(style.Render(plain[j : j+len(query)]))
+			plain, lower = plain[j+len(query):], lower[j+len(query):]
+		}
+		out[i] = b.String()
+	}
+	return out
+}
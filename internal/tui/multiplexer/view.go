@@ -0,0 +1,269 @@
+package multiplexer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adammpkins/OmniPath/internal/tui"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// minListedSessions is how many lines the session list takes at least, so
+// the output doesn't move as sessions are added.
+const minListedSessions = 4
+
+// Terminal dimensions assumed until the first tea.WindowSizeMsg arrives.
+const (
+	defaultWidth  = 80
+	defaultHeight = 28
+)
+
+// minPaneWidth is the narrowest a side-by-side pane may get before the split
+// view falls back to stacking panes vertically.
+const minPaneWidth = 40
+
+// sessionColors are assigned to sessions by position, so a session keeps its
+// color for the whole run.
+var sessionColors = []lipgloss.Color{"39", "208", "170", "42", "220", "75", "203", "141"}
+
+// sessionStyle returns the style used for the name of the session at index.
+func sessionStyle(index int) lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(sessionColors[index%len(sessionColors)])
+}
+
+// startedFormat is how a session's start time is shown in the header.
+const startedFormat = "15:04:05"
+
+// prefixLines prepends "[name] " in the session's color to each line when
+// the Prefix option is set.
+func (m *multiplexerModel) prefixLines(index int, lines []string) []string {
+	if !m.opts.Prefix {
+		return lines
+	}
+	prefix := sessionStyle(index).Render("["+m.sessions[index].Name+"]") + " "
+	prefixed := make([]string, len(lines))
+	for i, line := range lines {
+		prefixed[i] = prefix + line
+	}
+	return prefixed
+}
+
+// size returns the terminal dimensions, falling back to defaults before the
+// terminal has reported its size.
+func (m *multiplexerModel) size() (int, int) {
+	w, h := m.width, m.height
+	if w == 0 {
+		w = defaultWidth
+	}
+	if h == 0 {
+		h = defaultHeight
+	}
+	return w, h
+}
+
+// sideBySide reports whether the split view lays panes out in columns.
+func (m *multiplexerModel) sideBySide() bool {
+	w, _ := m.size()
+	n := len(m.sessions)
+	return (w-(n-1))/n >= minPaneWidth
+}
+
+// listedSessions returns the index of the first session in the session list
+// and how many are listed. All of them are listed while they fit in a third
+// of the terminal; otherwise the list shows a window around the active one.
+func (m *multiplexerModel) listedSessions() (first, n int) {
+	_, h := m.size()
+	n = min(len(m.sessions), max(h/3, minListedSessions))
+	if m.activeIndex >= n {
+		first = m.activeIndex - n + 1
+	}
+	return first, n
+}
+
+// headerHeight returns the number of lines above the active session's
+// output in the single view: the "Sessions:" line, the session list and the
+// hint line.
+func (m *multiplexerModel) headerHeight() int {
+	_, n := m.listedSessions()
+	return 1 + max(n, minListedSessions) + 1
+}
+
+// paneSize returns the width and number of output lines of a single pane in
+// the current layout.
+func (m *multiplexerModel) paneSize() (int, int) {
+	w, h := m.size()
+	if !m.split {
+		// A blank line and the title sit between the header and the output.
+		return w, max(h-m.headerHeight()-2, 1)
+	}
+	n := len(m.sessions)
+	// One status line at the top, and one title line per pane.
+	if m.sideBySide() {
+		return (w - (n - 1)) / n, max(h-2, 1)
+	}
+	return w, max((h-1)/n-1, 1)
+}
+
+// paneHeight returns the number of output lines visible for a session.
+func (m *multiplexerModel) paneHeight() int {
+	_, h := m.paneSize()
+	return h
+}
+
+// visibleOutput returns the slice of a session's output that fits in a pane
+// of the given height, and whether the view is scrolled away from the tail.
+// Search matches are highlighted in the active session.
+func (m *multiplexerModel) visibleOutput(index int, snap tui.Snapshot, height int) ([]string, bool) {
+	pos := m.scroll[index]
+	lines := snap.Lines
+	start := len(lines) - height
+	if pos.pinned {
+		start = pos.top - snap.Offset
+		if start > len(lines)-height {
+			start = len(lines) - height
+		}
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + height
+	if end > len(lines) {
+		end = len(lines)
+	}
+	visible := lines[start:end]
+	if index == m.activeIndex {
+		visible = m.highlightMatches(visible, snap.Offset+start)
+	}
+	return visible, end < len(lines)
+}
+
+// fitWidth truncates or pads s so it occupies exactly width cells.
+func fitWidth(s string, width int) string {
+	s = ansi.Truncate(s, width, "")
+	if pad := width - ansi.StringWidth(s); pad > 0 {
+		s += strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+// hint returns the shutdown notice while quitting, the search prompt or
+// position, the status message, a reminder of how to restart the active
+// session when it has exited, or else how to show the help.
+func (m *multiplexerModel) hint() string {
+	if m.quitting {
+		return "Stopping services... press " + keys.Quit.Help().Key + " again to force quit."
+	}
+	if m.prefixed {
+		return keys.Prefix.Help().Key + " - press a command key, or " + helpKey(keys.Help) + " for help."
+	}
+	if h := m.searchHint(); h != "" && (m.search.typing || m.status == "") {
+		return h
+	}
+	if m.status != "" {
+		return m.status
+	}
+	if m.sessions[m.activeIndex].Exited() && m.opts.Restart != nil {
+		return "Process exited - press " + helpKey(keys.Restart) + " to restart it."
+	}
+	return "Press " + helpKey(keys.Help) + " for help."
+}
+
+func (m *multiplexerModel) View() string {
+	if m.showHelp {
+		w, h := m.size()
+		return tui.HelpOverlay(m.sessionView(), keys, withPrefix(keys.Help)[0], w, h)
+	}
+	return m.sessionView()
+}
+
+// sessionView shows the sessions, either the active one below the session
+// list or all of them tiled.
+func (m *multiplexerModel) sessionView() string {
+	if m.split {
+		return m.splitView()
+	}
+	first, n := m.listedSessions()
+	title := "Sessions:"
+	if n < len(m.sessions) {
+		title = fmt.Sprintf("Sessions (%d-%d of %d):", first, first+n-1, len(m.sessions))
+	}
+	headerLines := []string{title}
+	for i := first; i < first+n; i++ {
+		sess := m.sessions[i]
+		marker := "  "
+		if i == m.activeIndex {
+			marker = "> "
+		}
+		line := fmt.Sprintf("%s%d: %s", marker, i, sessionStyle(i).Render(sess.Name))
+		if started := sess.Started(); !started.IsZero() {
+			line += " (started " + started.Format(startedFormat) + ")"
+		}
+		if status := sess.Status(); status != "" {
+			line += " " + status
+		}
+		headerLines = append(headerLines, line)
+	}
+	for len(headerLines) < m.headerHeight()-1 {
+		headerLines = append(headerLines, "")
+	}
+	headerLines = append(headerLines, m.hint())
+	header := strings.Join(headerLines, "\n")
+	lines, scrolled := m.visibleOutput(m.activeIndex, m.sessions[m.activeIndex].Snapshot(), m.paneHeight())
+	outputTitle := "--- Active Session Output ---"
+	if scrolled {
+		outputTitle += " [scrolled back, " + helpKey(keys.PageDown) + " to follow]"
+	}
+	lines = m.prefixLines(m.activeIndex, lines)
+	return header + "\n\n" + outputTitle + "\n" + strings.Join(lines, "\n")
+}
+
+// splitView tiles every session, side by side when the terminal is wide
+// enough and stacked otherwise. Each pane is clipped to its own area.
+func (m *multiplexerModel) splitView() string {
+	width, height := m.paneSize()
+	panes := make([][]string, len(m.sessions))
+	for i, sess := range m.sessions {
+		snap := sess.Snapshot()
+		lines, scrolled := m.visibleOutput(i, snap, height)
+		title := fmt.Sprintf(" %d: %s ", i, sess.Name)
+		if status := snap.Status(); status != "" {
+			title += status + " "
+		}
+		if scrolled {
+			title += "[scrolled] "
+		}
+		title = fitWidth(title, width)
+		if i == m.activeIndex {
+			title = sessionStyle(i).Reverse(true).Bold(true).Render(title)
+		} else {
+			title = sessionStyle(i).Render(title)
+		}
+		pane := []string{title}
+		for _, line := range m.prefixLines(i, lines) {
+			pane = append(pane, fitWidth(line, width))
+		}
+		for len(pane) < height+1 {
+			pane = append(pane, strings.Repeat(" ", width))
+		}
+		panes[i] = pane
+	}
+
+	status := m.hint()
+	if !m.sideBySide() {
+		var rows []string
+		for _, pane := range panes {
+			rows = append(rows, pane...)
+		}
+		return status + "\n" + strings.Join(rows, "\n")
+	}
+	rows := make([]string, height+1)
+	for r := range rows {
+		cols := make([]string, len(panes))
+		for i, pane := range panes {
+			cols[i] = pane[r]
+		}
+		rows[r] = strings.Join(cols, "│")
+	}
+	return status + "\n" + strings.Join(rows, "\n")
+}
@@ -0,0 +1,62 @@
+package multiplexer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func sessionNames(n int) []string {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("svc%d", i)
+	}
+	return names
+}
+
+// fill gives every session more output than fits on the screen.
+func fill(m *multiplexerModel) {
+	for _, sess := range m.sessions {
+		for i := 0; i < 100; i++ {
+			fmt.Fprintf(sess, "%s line %d\n", sess.Name, i)
+		}
+	}
+}
+
+func TestSingleViewFitsTheTerminal(t *testing.T) {
+	for _, n := range []int{1, 4, 5, 7, 12, 30} {
+		m, _ := newTestModel(t, sessionNames(n)...)
+		fill(m)
+		m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+		for _, active := range []int{0, n - 1} {
+			m.activeIndex = active
+			lines := strings.Split(m.View(), "\n")
+			if len(lines) != 24 {
+				t.Errorf("%d sessions, session %d active: view has %d lines, want 24", n, active, len(lines))
+			}
+			if !strings.HasPrefix(lines[0], "Sessions") {
+				t.Errorf("%d sessions: first line is %q, want the session list", n, lines[0])
+			}
+			if want := fmt.Sprintf("> %d: svc%d", active, active); !strings.Contains(ansi.Strip(m.View()), want) {
+				t.Errorf("%d sessions: the active session %d isn't listed", n, active)
+			}
+		}
+	}
+}
+
+func TestPageUpScrollsByTheVisiblePane(t *testing.T) {
+	m, _ := newTestModel(t, sessionNames(7)...)
+	fill(m)
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	press(m, tea.KeyMsg{Type: tea.KeyPgUp})
+
+	lines := strings.Split(ansi.Strip(m.View()), "\n")
+	last := lines[len(lines)-1]
+	// The pane showed up to line 99 and now ends one page earlier.
+	if want := fmt.Sprintf("svc0 line %d", 99-m.paneHeight()); last != want {
+		t.Errorf("after PgUp the last line is %q, want %q", last, want)
+	}
+}
@@ -2,34 +2,143 @@ package tui
 
 import (
 	"fmt"
+	"io"
+	"sort"
+	"time"
 
 	"github.com/adammpkins/OmniPath/internal/docs"
+	"github.com/adammpkins/OmniPath/internal/links"
+	"github.com/adammpkins/OmniPath/internal/recent"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
+// sourceStyle tags a dependency with the reason it was detected.
+var sourceStyle = lipgloss.NewStyle().Faint(true).Italic(true)
+
 // dependencyItem wraps docs.DependencyDocs so it satisfies the list.Item interface.
 type dependencyItem docs.DependencyDocs
 
-func (d dependencyItem) Title() string       { return d.Name }
-func (d dependencyItem) Description() string { return d.DocURL }
+func (d dependencyItem) Title() string { return d.Name }
+func (d dependencyItem) Description() string {
+	if d.Source == "" {
+		return d.DocURL
+	}
+	return d.DocURL + " " + sourceStyle.Render("("+d.Source+")")
+}
 func (d dependencyItem) FilterValue() string { return d.Name }
 
-// selectorModel defines the Bubbletea model for our dependency selector.
-type selectorModel struct {
-	list list.Model
+// categoryLabels are the section headers shown above each dependency category.
+var categoryLabels = map[string]string{
+	docs.CategoryLanguage:  "Languages",
+	docs.CategoryFramework: "Frameworks",
+	docs.CategoryLibrary:   "Libraries",
+	docs.CategoryTooling:   "Tooling",
+	docs.CategoryInfra:     "Infrastructure",
 }
 
-// newSelectorModel creates a new selector model with our dependency items.
-// Here, we set the height to 20 to allow at least 10 items to be visible.
-func newSelectorModel(deps []docs.DependencyDocs) selectorModel {
-	items := make([]list.Item, len(deps))
-	for i, dep := range deps {
+// categoryHeaderStyle styles the section headers of the dependency selector.
+var categoryHeaderStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7D56F4")).PaddingLeft(2)
+
+// groupedDelegate renders dependencies under a header for their category.
+// The header takes the place of the blank line the default delegate leaves
+// between items, and is shown above the first item of each category on the
+// page.
+type groupedDelegate struct {
+	list.DefaultDelegate
+}
+
+func newGroupedDelegate() groupedDelegate {
+	d := list.NewDefaultDelegate()
+	d.SetSpacing(0)
+	return groupedDelegate{d}
+}
+
+func (d groupedDelegate) Height() int { return d.DefaultDelegate.Height() + 1 }
+
+func (d groupedDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	header := ""
+	if dep, ok := item.(dependencyItem); ok {
+		visible := m.VisibleItems()
+		pageStart := m.Paginator.Page * m.Paginator.PerPage
+		prev, hasPrev := dependencyItem{}, false
+		if index > pageStart && index-1 < len(visible) {
+			prev, hasPrev = visible[index-1].(dependencyItem)
+		}
+		if !hasPrev || prev.Category != dep.Category {
+			label := categoryLabels[dep.Category]
+			if label == "" {
+				label = "Other"
+			}
+			header = categoryHeaderStyle.Render(label)
+		}
+	}
+	_, _ = fmt.Fprintln(w, header)
+	d.DefaultDelegate.Render(w, m, index, item)
+}
+
+// newDependencySelector creates a selector listing deps grouped by category.
+// Filtering keeps the grouping: matches are ranked within their category.
+func newDependencySelector(title string, deps []docs.DependencyDocs) selectorModel {
+	sorted := append([]docs.DependencyDocs(nil), deps...)
+	docs.Sort(sorted)
+	items := make([]list.Item, len(sorted))
+	for i, dep := range sorted {
 		items[i] = dependencyItem(dep)
 	}
-	// Adjust width and height as needed. Here, height is increased to 20.
-	l := list.New(items, list.NewDefaultDelegate(), 40, 20)
-	l.Title = "Select Dependency"
+
+	m := newSelectorModel(title, items)
+	m.list.SetDelegate(newGroupedDelegate())
+	m.list.Filter = func(term string, targets []string) []list.Rank {
+		ranks := list.DefaultFilter(term, targets)
+		sort.SliceStable(ranks, func(i, j int) bool {
+			return docs.CategoryRank(sorted[ranks[i].Index].Category) < docs.CategoryRank(sorted[ranks[j].Index].Category)
+		})
+		return ranks
+	}
+	return m
+}
+
+// linkItem wraps links.Link so it satisfies the list.Item interface.
+type linkItem links.Link
+
+func (l linkItem) Title() string       { return l.Alias }
+func (l linkItem) Description() string { return l.URL }
+func (l linkItem) FilterValue() string { return l.Alias }
+
+// recentItem wraps recent.Entry so it satisfies the list.Item interface.
+type recentItem recent.Entry
+
+func (r recentItem) Title() string { return r.Name }
+func (r recentItem) Description() string {
+	times := "once"
+	if r.Count != 1 {
+		times = fmt.Sprintf("%d times", r.Count)
+	}
+	return r.URL + " " + sourceStyle.Render(fmt.Sprintf("(opened %s, last %s)", times, r.LastOpened.Format(time.DateOnly)))
+}
+func (r recentItem) FilterValue() string { return r.Name }
+
+// selectorModel defines the Bubbletea model for picking a single list item.
+type selectorModel struct {
+	list   list.Model
+	chosen bool // Whether the user confirmed a selection rather than quitting.
+
+	// onChoose, when set, is called with the highlighted item on Enter and
+	// the selector stays open until the user quits with q or Esc.
+	onChoose func(list.Item) error
+}
+
+// newSelectorModel creates a new selector model with the given title and items.
+// Here, we set the height to 20 to allow at least 10 items to be visible.
+func newSelectorModel(title string, items []list.Item) selectorModel {
+	// Adjust width and height as needed. Here, height is increased to 20 and
+	// the width leaves room for a URL and its detection source.
+	l := list.New(items, list.NewDefaultDelegate(), 80, 20)
+	l.Title = title
+	// Press "/" to fuzzy-filter the list by item name.
+	l.SetFilteringEnabled(true)
 	return selectorModel{list: l}
 }
 
@@ -43,11 +152,32 @@ func (m selectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// Use the full width, but keep the list compact on tall terminals.
+		m.list.SetSize(msg.Width, min(msg.Height, 20))
+		return m, nil
 	case tea.KeyMsg:
+		// While the filter is being typed, keys (including Enter, which applies
+		// the filter) belong to the list.
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
 		switch msg.String() {
-		// When the user presses Enter, we quit the TUI.
+		// When the user presses Enter, we quit the TUI unless it stays open.
 		case "enter":
-			return m, tea.Quit
+			item := m.list.SelectedItem()
+			if m.onChoose == nil {
+				m.chosen = item != nil
+				return m, tea.Quit
+			}
+			if item == nil {
+				return m, nil
+			}
+			status := "Opened " + item.(list.DefaultItem).Title()
+			if err := m.onChoose(item); err != nil {
+				status = "Error: " + err.Error()
+			}
+			return m, m.list.NewStatusMessage(status)
 		}
 	}
 
@@ -60,27 +190,90 @@ func (m selectorModel) View() string {
 	return m.list.View()
 }
 
-// SelectDependency launches the TUI and returns the dependency selected by the user.
-func SelectDependency(deps []docs.DependencyDocs) (docs.DependencyDocs, error) {
-	model := newSelectorModel(deps)
+// runSelector launches the TUI and returns the item selected by the user, or
+// nil if they quit without choosing one.
+func runSelector(title string, items []list.Item) (list.Item, error) {
+	return runSelectorModel(newSelectorModel(title, items))
+}
+
+// runSelectorModel runs a prepared selector model until the user quits.
+func runSelectorModel(model selectorModel) (list.Item, error) {
 	// Use Run() to capture the final model.
-	finalModel, err := tea.NewProgram(model).Run()
+	finalModel, err := RunProgram(tea.NewProgram(model))
 	if err != nil {
-		return docs.DependencyDocs{}, err
+		return nil, err
 	}
 
 	// Assert the final model to our selectorModel type.
 	m, ok := finalModel.(selectorModel)
 	if !ok {
-		return docs.DependencyDocs{}, fmt.Errorf("unexpected model type")
+		return nil, fmt.Errorf("unexpected model type")
 	}
+	return m.selection(), nil
+}
 
-	selectedItem := m.list.SelectedItem()
+// selection returns the item the user chose, or nil if they quit without
+// choosing one.
+func (m selectorModel) selection() list.Item {
+	if !m.chosen {
+		return nil
+	}
+	// SelectedItem is relative to the filtered items when a filter is applied.
+	return m.list.SelectedItem()
+}
+
+// SelectDependency launches the TUI and returns the dependency selected by the user.
+func SelectDependency(deps []docs.DependencyDocs) (docs.DependencyDocs, error) {
+	selectedItem, err := runSelectorModel(newDependencySelector("Select Dependency", deps))
+	if err != nil {
+		return docs.DependencyDocs{}, err
+	}
 	if dep, ok := selectedItem.(dependencyItem); ok {
-		return docs.DependencyDocs{
-			Name:   dep.Name,
-			DocURL: dep.DocURL,
-		}, nil
+		return docs.DependencyDocs(dep), nil
 	}
 	return docs.DependencyDocs{}, fmt.Errorf("no dependency selected")
 }
+
+// BrowseDependencies launches the TUI and calls open for each dependency the
+// user chooses, staying open until they quit so several docs can be opened.
+func BrowseDependencies(deps []docs.DependencyDocs, open func(docs.DependencyDocs) error) error {
+	model := newDependencySelector("Browse Dependencies", deps)
+	model.onChoose = func(item list.Item) error {
+		return open(docs.DependencyDocs(item.(dependencyItem)))
+	}
+	_, err := runSelectorModel(model)
+	return err
+}
+
+// SelectLink launches the TUI and returns the project link selected by the user.
+func SelectLink(ls []links.Link) (links.Link, error) {
+	items := make([]list.Item, len(ls))
+	for i, l := range ls {
+		items[i] = linkItem(l)
+	}
+	selectedItem, err := runSelector("Select Link", items)
+	if err != nil {
+		return links.Link{}, err
+	}
+	if l, ok := selectedItem.(linkItem); ok {
+		return links.Link(l), nil
+	}
+	return links.Link{}, fmt.Errorf("no link selected")
+}
+
+// SelectRecent launches the TUI and returns the recently opened documentation
+// page selected by the user. Entries are listed in the order given.
+func SelectRecent(entries []recent.Entry) (recent.Entry, error) {
+	items := make([]list.Item, len(entries))
+	for i, e := range entries {
+		items[i] = recentItem(e)
+	}
+	selectedItem, err := runSelector("Recent Docs", items)
+	if err != nil {
+		return recent.Entry{}, err
+	}
+	if e, ok := selectedItem.(recentItem); ok {
+		return recent.Entry(e), nil
+	}
+	return recent.Entry{}, fmt.Errorf("no documentation selected")
+}
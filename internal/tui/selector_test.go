@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/adammpkins/OmniPath/internal/docs"
+	"github.com/charmbracelet/bubbles/cursor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// send passes msgs to the selector one at a time, and the messages their
+// commands produce after each, as a running program would.
+func send(m selectorModel, msgs ...tea.Msg) selectorModel {
+	for len(msgs) > 0 {
+		msg := msgs[0]
+		msgs = msgs[1:]
+		if _, ok := msg.(tea.QuitMsg); ok {
+			break
+		}
+		model, cmd := m.Update(msg)
+		m = model.(selectorModel)
+		msgs = append(run(cmd), msgs...)
+	}
+	return m
+}
+
+// run runs cmd and returns the messages it produces.
+func run(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	switch msg := cmd().(type) {
+	case nil:
+		return nil
+	case tea.BatchMsg:
+		var msgs []tea.Msg
+		for _, cmd := range msg {
+			msgs = append(msgs, run(cmd)...)
+		}
+		return msgs
+	default:
+		return []tea.Msg{msg}
+	}
+}
+
+// TestSelectFiltered filters the dependency list and chooses the first
+// match, which must be the one returned rather than the first dependency.
+func TestSelectFiltered(t *testing.T) {
+	m := newDependencySelector("Select Dependency", []docs.DependencyDocs{
+		{Name: "cobra", DocURL: "https://cobra.dev", Category: docs.CategoryLibrary},
+		{Name: "lipgloss", DocURL: "https://github.com/charmbracelet/lipgloss", Category: docs.CategoryLibrary},
+		{Name: "viper", DocURL: "https://github.com/spf13/viper", Category: docs.CategoryLibrary},
+	})
+	// A blinking cursor's commands wait for the next blink.
+	m.list.FilterInput.Cursor.SetMode(cursor.CursorStatic)
+
+	msgs := []tea.Msg{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")}}
+	for _, r := range "viper" {
+		msgs = append(msgs, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	// The first Enter applies the filter, the second chooses.
+	msgs = append(msgs, tea.KeyMsg{Type: tea.KeyEnter}, tea.KeyMsg{Type: tea.KeyEnter})
+	m = send(m, msgs...)
+
+	if dep, ok := m.selection().(dependencyItem); !ok || dep.Name != "viper" {
+		t.Errorf("selection = %v, want viper", m.selection())
+	}
+}
@@ -1,8 +1,11 @@
 package tui
 
 import (
+	"fmt"
 	"io"
 	"os/exec"
+	"sync"
+	"time"
 )
 
 // Service represents a runnable service with a name, command, and a flag indicating if it should run interactively.
@@ -10,12 +13,240 @@ type Service struct {
 	Name        string
 	Command     string
 	Interactive bool
+	Dir         string // Working directory to run the command in; empty means the current directory.
 }
 
-// Session represents a running service with its stdin pipe, accumulated output, and command reference.
+// Health is the outcome of a session's startup health check.
+type Health int
+
+const (
+	HealthUnchecked Health = iota // No health check is running.
+	HealthStarting                // Waiting for the service to listen on its port.
+	HealthReady                   // The service accepted a connection.
+	HealthFailed                  // The service didn't listen within the check's window.
+)
+
+// Session represents a running service with its input, accumulated output, and command reference.
+// The process state and output are written by reader goroutines while the UI
+// reads them, so they are only reachable through the session's methods.
 type Session struct {
-	Name   string         // The name of the service.
-	Stdin  io.WriteCloser // The pipe to send input to the process.
-	Output string         // Accumulated output from the process.
-	Cmd    *exec.Cmd      // Reference to the running command.
+	Name    string  // The name of the service.
+	Service Service // The service being run, kept so the session can be restarted.
+
+	mu       sync.Mutex
+	stdin    io.WriteCloser // Where input to the process is written: its terminal or stdin pipe.
+	output   *OutputBuffer  // Most recent output from the process.
+	cmd      *exec.Cmd      // Reference to the running command.
+	exited   bool           // Whether the process has exited.
+	exitCode int            // The process exit code once exited is set; -1 if killed by a signal.
+	started  time.Time      // When the current process was started.
+	health   Health         // Result of the current process's health check.
+	done     chan struct{}  // Closed when the current process exits.
+	changed  chan struct{}  // Signalled, without blocking, whenever output or state changes.
+	cols     int            // Size of the pane showing the session, once known.
+	rows     int
+}
+
+// resizer is implemented by the input of processes running on a terminal,
+// which is told the size of the pane showing their output.
+type resizer interface {
+	Resize(cols, rows int) error
+}
+
+// Snapshot is a consistent, point-in-time copy of a session's state.
+type Snapshot struct {
+	Lines    []string // Buffered output lines, oldest first.
+	Offset   int      // Absolute index of Lines[0]; see OutputBuffer.Offset.
+	Exited   bool
+	ExitCode int
+	Started  time.Time // When the current process was started.
+	Health   Health
+}
+
+// NewSession creates a session for a service that keeps up to scrollback
+// lines of output.
+func NewSession(s Service, scrollback int) *Session {
+	return &Session{
+		Name:    s.Name,
+		Service: s,
+		output:  NewOutputBuffer(scrollback),
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// Write appends process output to the session. It implements io.Writer so
+// reader goroutines can copy a pipe straight into the session.
+func (s *Session) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	n, err := s.output.Write(p)
+	s.mu.Unlock()
+	s.notify()
+	return n, err
+}
+
+// Changed returns a channel that receives a value after the session's output
+// or process state changes. Changes made while no one is receiving are
+// coalesced into a single pending value.
+func (s *Session) Changed() <-chan struct{} {
+	return s.changed
+}
+
+// notify signals a change without blocking.
+func (s *Session) notify() {
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+// ClearOutput discards the session's buffered output.
+func (s *Session) ClearOutput() {
+	s.mu.Lock()
+	s.output.Clear()
+	s.mu.Unlock()
+	s.notify()
+}
+
+// Attach records a newly started process and its input, marking the session
+// as running. If the process runs on a terminal, stdin may implement
+// Resize(cols, rows int) error to have the terminal follow the session's size.
+func (s *Session) Attach(cmd *exec.Cmd, stdin io.WriteCloser) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cmd = cmd
+	s.stdin = stdin
+	if r, ok := stdin.(resizer); ok && s.cols > 0 {
+		_ = r.Resize(s.cols, s.rows)
+	}
+	s.exited = false
+	s.exitCode = 0
+	s.started = time.Now()
+	s.health = HealthUnchecked
+	s.done = make(chan struct{})
+	s.notify()
+}
+
+// SetExited records that the process finished with the given exit code.
+func (s *Session) SetExited(code int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.exited = true
+	s.exitCode = code
+	if s.done != nil {
+		close(s.done)
+		s.done = nil
+	}
+	s.notify()
+}
+
+// SetHealth records the progress of the session's health check.
+func (s *Session) SetHealth(h Health) {
+	s.mu.Lock()
+	s.health = h
+	s.mu.Unlock()
+	s.notify()
+}
+
+// Done returns a channel that is closed when the session's current process
+// exits. The channel of a session that hasn't been started is never closed,
+// and one that has already exited is closed.
+func (s *Session) Done() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.exited {
+		closed := make(chan struct{})
+		close(closed)
+		return closed
+	}
+	return s.done
+}
+
+// Cmd returns the most recently started command, or nil if none was started.
+func (s *Session) Cmd() *exec.Cmd {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cmd
+}
+
+// Started returns when the session's current process was started.
+func (s *Session) Started() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.started
+}
+
+// Exited reports whether the session's process has exited.
+func (s *Session) Exited() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.exited
+}
+
+// Resize records the size of the pane showing the session and passes it on to
+// the process's terminal, if it has one.
+func (s *Session) Resize(cols, rows int) {
+	s.mu.Lock()
+	if cols == s.cols && rows == s.rows {
+		s.mu.Unlock()
+		return
+	}
+	s.cols, s.rows = cols, rows
+	stdin := s.stdin
+	s.mu.Unlock()
+	if r, ok := stdin.(resizer); ok {
+		_ = r.Resize(cols, rows)
+	}
+}
+
+// SendInput writes p to the process's stdin. The lock isn't held during the
+// write so a full pipe can't block output from being recorded.
+func (s *Session) SendInput(p []byte) error {
+	s.mu.Lock()
+	stdin := s.stdin
+	s.mu.Unlock()
+	if stdin == nil {
+		return nil
+	}
+	_, err := stdin.Write(p)
+	return err
+}
+
+// Snapshot returns a copy of the session's output and process state.
+func (s *Session) Snapshot() Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Snapshot{
+		Lines:    s.output.Lines(),
+		Offset:   s.output.Offset(),
+		Exited:   s.exited,
+		ExitCode: s.exitCode,
+		Started:  s.started,
+		Health:   s.health,
+	}
+}
+
+// Status returns a short label describing the session's process state, or an
+// empty string while it is running without a health check.
+func (s *Session) Status() string {
+	s.mu.Lock()
+	snap := Snapshot{Exited: s.exited, ExitCode: s.exitCode, Health: s.health}
+	s.mu.Unlock()
+	return snap.Status()
+}
+
+// Status returns a short label describing the snapshot's process state, or an
+// empty string while it is running without a health check.
+func (s Snapshot) Status() string {
+	if s.Exited {
+		return fmt.Sprintf("[exited %d]", s.ExitCode)
+	}
+	switch s.Health {
+	case HealthStarting:
+		return "[starting…]"
+	case HealthReady:
+		return "[ready]"
+	case HealthFailed:
+		return "[not responding]"
+	}
+	return ""
 }
@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
+)
+
+// resetSequence undoes what a Bubbletea program may have switched on: mouse
+// reporting, bracketed paste and the alternate screen. It then shows the
+// cursor again.
+const resetSequence = "\x1b[?1002l\x1b[?1003l\x1b[?1006l\x1b[?2004l\x1b[?1049l\x1b[?25h"
+
+// savedTerminal is the terminal state from before the running program took
+// over, restored if anything panics while the program runs.
+var savedTerminal struct {
+	mu    sync.Mutex
+	state *term.State // Nil when stdin isn't a terminal or nothing runs.
+}
+
+// RunProgram runs p like p.Run, but makes sure a panic never leaves the
+// terminal in raw mode: the terminal state is saved first and restored if p
+// panics or fails, and the panic is returned as an error with its stack.
+// Goroutines running alongside the program should defer RestoreOnPanic.
+func RunProgram(p *tea.Program) (model tea.Model, err error) {
+	saveTerminal()
+	defer func() {
+		if r := recover(); r != nil {
+			restoreTerminal()
+			err = fmt.Errorf("terminal UI crashed: %v\n%s", r, debug.Stack())
+		}
+		forgetTerminal()
+	}()
+	model, err = p.Run()
+	if err != nil {
+		restoreTerminal()
+	}
+	return model, err
+}
+
+// RestoreOnPanic restores the terminal saved by RunProgram if the calling
+// goroutine is panicking, and then continues panicking. Deferring it at the
+// top of a goroutine means a crash there, which kills the process before
+// the program can clean up, still leaves the shell usable.
+func RestoreOnPanic() {
+	if r := recover(); r != nil {
+		restoreTerminal()
+		panic(r)
+	}
+}
+
+// saveTerminal records the state of the terminal on stdin, if it is one.
+func saveTerminal() {
+	savedTerminal.mu.Lock()
+	defer savedTerminal.mu.Unlock()
+	savedTerminal.state = nil
+	if fd := os.Stdin.Fd(); term.IsTerminal(fd) {
+		if state, err := term.GetState(fd); err == nil {
+			savedTerminal.state = state
+		}
+	}
+}
+
+// forgetTerminal drops the saved state once the program has exited cleanly.
+func forgetTerminal() {
+	savedTerminal.mu.Lock()
+	savedTerminal.state = nil
+	savedTerminal.mu.Unlock()
+}
+
+// restoreTerminal puts the terminal back into the saved state and resets
+// the modes a program may have enabled. It does nothing unless a state was
+// saved.
+func restoreTerminal() {
+	savedTerminal.mu.Lock()
+	defer savedTerminal.mu.Unlock()
+	if savedTerminal.state == nil {
+		return
+	}
+	_ = term.Restore(os.Stdin.Fd(), savedTerminal.state)
+	if term.IsTerminal(os.Stdout.Fd()) {
+		fmt.Fprint(os.Stdout, resetSequence)
+	}
+}
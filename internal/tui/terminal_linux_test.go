@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/creack/pty"
+	"golang.org/x/sys/unix"
+)
+
+// TestRestoreOnPanic puts a pseudo-terminal into raw mode, as a running
+// program does, panics in a goroutine deferring RestoreOnPanic, and checks
+// that the terminal is back in its saved state with the modes reset.
+func TestRestoreOnPanic(t *testing.T) {
+	ptmx, tty, err := pty.Open()
+	if err != nil {
+		t.Skipf("no pseudo-terminal: %v", err)
+	}
+	defer ptmx.Close()
+	defer tty.Close()
+
+	stdin, stdout := os.Stdin, os.Stdout
+	os.Stdin, os.Stdout = tty, tty
+	defer func() { os.Stdin, os.Stdout = stdin, stdout }()
+
+	saveTerminal()
+	defer forgetTerminal()
+	if _, err := term.MakeRaw(tty.Fd()); err != nil {
+		t.Fatal(err)
+	}
+	if echoing(t, tty) {
+		t.Fatal("terminal still echoes in raw mode")
+	}
+
+	recovered := make(chan any)
+	go func() {
+		defer func() { recovered <- recover() }()
+		defer RestoreOnPanic()
+		panic("simulated crash")
+	}()
+	if r := <-recovered; r != "simulated crash" {
+		t.Errorf("RestoreOnPanic didn't re-panic with the original value, got %v", r)
+	}
+
+	if !echoing(t, tty) {
+		t.Error("terminal was left in raw mode")
+	}
+	output := make(chan string)
+	go func() {
+		buf := make([]byte, 256)
+		n, _ := ptmx.Read(buf)
+		output <- string(buf[:n])
+	}()
+	select {
+	case got := <-output:
+		if !strings.Contains(got, resetSequence) {
+			t.Errorf("terminal received %q, want the reset sequence", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("reset sequence wasn't written")
+	}
+}
+
+// echoing reports whether the terminal echoes input, which raw mode turns off.
+func echoing(t *testing.T, tty *os.File) bool {
+	t.Helper()
+	termios, err := unix.IoctlGetTermios(int(tty.Fd()), unix.TCGETS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return termios.Lflag&unix.ECHO != 0
+}
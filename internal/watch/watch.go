@@ -0,0 +1,128 @@
+package watch
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDebounce is how long a burst of changes must stay quiet before it
+// is reported, so saving several files, or an editor writing a file in
+// steps, causes a single restart.
+const DefaultDebounce = 300 * time.Millisecond
+
+// Watcher reports changes to files with chosen extensions anywhere below a
+// directory. Directories created later are watched too.
+type Watcher struct {
+	fs       *fsnotify.Watcher
+	exts     map[string]bool
+	ignore   func(dir string) bool
+	debounce time.Duration
+	changes  chan string
+	done     chan struct{}
+}
+
+// New watches root and the directories below it, except those ignore
+// reports true for, for changes to files whose extension, such as ".go", is
+// in extensions. No extensions means every file. Each burst of changes is
+// reported once debounce has passed without another.
+func New(root string, extensions []string, ignore func(dir string) bool, debounce time.Duration) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{
+		fs:       fsw,
+		exts:     make(map[string]bool),
+		ignore:   ignore,
+		debounce: debounce,
+		changes:  make(chan string),
+		done:     make(chan struct{}),
+	}
+	for _, ext := range extensions {
+		w.exts[strings.ToLower(ext)] = true
+	}
+	if err := w.addTree(root); err != nil {
+		fsw.Close()
+		return nil, err
+	}
+	go w.loop()
+	return w, nil
+}
+
+// Changes receives the path of the first file changed in each burst of
+// changes. It is closed when the watcher is.
+func (w *Watcher) Changes() <-chan string {
+	return w.changes
+}
+
+// Close stops watching.
+func (w *Watcher) Close() error {
+	close(w.done)
+	return w.fs.Close()
+}
+
+// addTree watches dir and every directory below it that isn't ignored.
+func (w *Watcher) addTree(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if path != dir && w.ignore != nil && w.ignore(path) {
+			return filepath.SkipDir
+		}
+		return w.fs.Add(path)
+	})
+}
+
+// matches reports whether a change to path should be reported.
+func (w *Watcher) matches(path string) bool {
+	return len(w.exts) == 0 || w.exts[strings.ToLower(filepath.Ext(path))]
+}
+
+func (w *Watcher) loop() {
+	defer close(w.changes)
+	var (
+		first string // First changed file of the pending burst.
+		timer <-chan time.Time
+	)
+	for {
+		select {
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			// New directories need watching of their own.
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if w.ignore == nil || !w.ignore(event.Name) {
+						_ = w.addTree(event.Name)
+					}
+					continue
+				}
+			}
+			if event.Op == fsnotify.Chmod || !w.matches(event.Name) {
+				continue
+			}
+			if first == "" {
+				first = event.Name
+			}
+			timer = time.After(w.debounce)
+		case _, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+		case <-timer:
+			select {
+			case w.changes <- first:
+			case <-w.done:
+				return
+			}
+			first, timer = "", nil
+		}
+	}
+}